@@ -0,0 +1,142 @@
+// Package alslgrbus provides an alslgr.Dumper publishing dumps to a message bus, e.g. NATS JetStream or Redis Streams,
+// through any client implementing Publisher. Adapters are a few lines long:
+//
+//	// NATS JetStream, subject is the subject of a stream.
+//	func (p jetStreamPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+//		_, err := p.js.Publish(ctx, subject, data)
+//		return err
+//	}
+//
+//	// Redis Streams, subject is the key of a stream.
+//	func (p redisPublisher) Publish(ctx context.Context, subject string, data []byte) error {
+//		return p.rdb.XAdd(ctx, &redis.XAddArgs{Stream: subject, Values: []any{"message", data}}).Err()
+//	}
+package alslgrbus
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	// Publisher publishes data to subject and returns once the bus has persisted it. It must not retain data after
+	// returning.
+	Publisher interface {
+		Publish(ctx context.Context, subject string, data []byte) error
+	}
+
+	Config struct {
+		Subject      string
+		SplitRecords bool
+		Delimiter    byte
+		Timeout      time.Duration
+
+		MaxAttempts int
+		RetryDelay  time.Duration
+	}
+
+	dumper struct {
+		publisher Publisher
+		config    Config
+
+		sleep func(time.Duration)
+	}
+)
+
+const (
+	DefaultMaxAttempts = 3
+	DefaultRetryDelay  = time.Millisecond * 100
+)
+
+// NewDumper creates a Dumper publishing each dump as a single message to Subject, or each record of a dump as a
+// separate message if SplitRecords is set. Records are delimited by Delimiter, '\n' by default. A message is published
+// up to MaxAttempts times, the delay between attempts starts with RetryDelay and doubles, each attempt limited by
+// Timeout if it is positive. Delivery is at-least-once: a failed dump is retried by the Logger as a whole, so messages
+// published before the failure are published again. The Dumper implements alslgr.BatchDumper, so when used by a Logger
+// with SplitRecords set every record written into it is published as a separate message, regardless of Delimiter.
+func NewDumper(publisher Publisher, config Config) alslgr.Dumper {
+	if config.Delimiter == 0 {
+		config.Delimiter = '\n'
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	return &dumper{
+		publisher: publisher,
+		config:    config,
+		sleep:     time.Sleep,
+	}
+}
+
+func (d *dumper) Dump(b []byte) error {
+	if !d.config.SplitRecords {
+		return d.publish(b)
+	}
+
+	for len(b) > 0 {
+		record := b
+		i := bytes.IndexByte(b, d.config.Delimiter)
+		if i >= 0 {
+			record, b = b[:i+1], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		err := d.publish(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *dumper) DumpMany(records [][]byte) error {
+	if !d.config.SplitRecords {
+		return d.publish(bytes.Join(records, nil))
+	}
+
+	for _, record := range records {
+		err := d.publish(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *dumper) publish(data []byte) error {
+	delay := d.config.RetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := d.publishOnce(data)
+		if err == nil || attempt >= d.config.MaxAttempts {
+			return err
+		}
+
+		d.sleep(delay)
+		delay *= 2
+	}
+}
+
+func (d *dumper) publishOnce(data []byte) error {
+	ctx, cancel := d.context()
+	defer cancel()
+
+	return d.publisher.Publish(ctx, d.config.Subject, data)
+}
+
+func (d *dumper) context() (context.Context, context.CancelFunc) {
+	if d.config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), d.config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
@@ -0,0 +1,110 @@
+package alslgrbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	TestPublisher struct {
+		failures  int
+		published []string
+	}
+)
+
+var (
+	errUnavailable = errors.New("UNAVAILABLE")
+)
+
+func (p *TestPublisher) Publish(_ context.Context, subject string, data []byte) error {
+	if p.failures > 0 {
+		p.failures--
+		return errUnavailable
+	}
+
+	p.published = append(p.published, fmt.Sprintf("%s/%q", subject, data))
+	return nil
+}
+
+func TestDumper(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Split    bool
+		Expected string
+	}{
+		{Name: "WHOLE DUMP", Split: false, Expected: `[logs/"A\nB\n"]`},
+		{Name: "SPLIT RECORDS", Split: true, Expected: `[logs/"A\n" logs/"B\n"]`},
+	}
+
+	for _, test := range tests {
+		p := &TestPublisher{failures: 1}
+		d := NewDumper(p, Config{Subject: "logs", SplitRecords: test.Split})
+
+		var delays []time.Duration
+		d.(*dumper).sleep = func(d time.Duration) {
+			delays = append(delays, d)
+		}
+
+		err := d.Dump([]byte("A\nB\n"))
+		if err != nil {
+			t.Errorf("TEST \"BUS %s\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		given := fmt.Sprint(p.published)
+		if given != test.Expected || len(delays) != 1 {
+			t.Errorf("TEST \"BUS %s\" FAILED: EXPECTED MESSAGES %s AFTER 1 RETRY GOT %s AFTER %d\n", test.Name, test.Expected, given, len(delays))
+		}
+	}
+}
+
+func TestDumpMany(t *testing.T) {
+	p := &TestPublisher{failures: 3}
+	d := NewDumper(p, Config{Subject: "logs", SplitRecords: true, MaxAttempts: 2})
+	d.(*dumper).sleep = func(time.Duration) {}
+
+	l := alslgr.NewLogger(1<<4, d)
+
+	for _, data := range []string{"A", "B"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"BUS DUMP MANY\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if !errors.Is(err, errUnavailable) {
+		t.Errorf("TEST \"BUS DUMP MANY\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", errUnavailable, err)
+	}
+
+	err = l.Close()
+	if err != nil {
+		t.Errorf("TEST \"BUS DUMP MANY\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expected := `[logs/"A" logs/"B"]`
+	given := fmt.Sprint(p.published)
+	if given != expected {
+		t.Errorf("TEST \"BUS DUMP MANY\" FAILED: EXPECTED MESSAGES %s GOT %s\n", expected, given)
+	}
+}
+
+func TestDumpManyWhole(t *testing.T) {
+	p := &TestPublisher{}
+	d := NewDumper(p, Config{Subject: "logs"})
+
+	err := d.(alslgr.BatchDumper).DumpMany([][]byte{[]byte("A\n"), []byte("B\n")})
+	if err != nil {
+		t.Errorf("TEST \"BUS DUMP MANY WHOLE\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expected := `[logs/"A\nB\n"]`
+	given := fmt.Sprint(p.published)
+	if given != expected {
+		t.Errorf("TEST \"BUS DUMP MANY WHOLE\" FAILED: EXPECTED MESSAGES %s GOT %s\n", expected, given)
+	}
+}
@@ -0,0 +1,244 @@
+// Package alslgrcloudwatch provides an alslgr.Dumper putting dumps into AWS CloudWatch Logs through any client
+// implementing Client, e.g. an adapter of the AWS SDK:
+//
+//	func (c sdkClient) PutLogEvents(ctx context.Context, group, stream string, events []alslgrcloudwatch.Event,
+//		sequenceToken *string) (*string, error) {
+//		input := &cloudwatchlogs.PutLogEventsInput{LogGroupName: &group, LogStreamName: &stream, SequenceToken: sequenceToken}
+//		for _, e := range events {
+//			input.LogEvents = append(input.LogEvents, types.InputLogEvent{Timestamp: aws.Int64(e.Timestamp.UnixMilli()),
+//				Message: aws.String(e.Message)})
+//		}
+//		output, err := c.api.PutLogEvents(ctx, input)
+//		var tokenErr *types.InvalidSequenceTokenException
+//		var throttled *types.ThrottlingException
+//		switch {
+//		case errors.As(err, &tokenErr):
+//			return nil, &alslgrcloudwatch.SequenceTokenError{Expected: tokenErr.ExpectedSequenceToken}
+//		case errors.As(err, &throttled):
+//			return nil, fmt.Errorf("%w: %v", alslgrcloudwatch.ErrThrottled, err)
+//		case err != nil:
+//			return nil, err
+//		}
+//		return output.NextSequenceToken, nil
+//	}
+package alslgrcloudwatch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	Event struct {
+		Timestamp time.Time
+		Message   string
+	}
+
+	// Client calls PutLogEvents and returns the next sequence token. It must return SequenceTokenError if the token is
+	// rejected and an error wrapping ErrThrottled if the call is throttled.
+	Client interface {
+		PutLogEvents(ctx context.Context, group, stream string, events []Event, sequenceToken *string) (*string, error)
+	}
+
+	// SequenceTokenError reports the sequence token expected by CloudWatch Logs instead of the one passed.
+	SequenceTokenError struct {
+		Expected *string
+	}
+
+	Config struct {
+		Group   string
+		Stream  string
+		Timeout time.Duration
+
+		MaxAttempts int
+		RetryDelay  time.Duration
+	}
+
+	dumper struct {
+		mx sync.Mutex
+
+		client Client
+		config Config
+
+		sequenceToken *string
+		events        []Event
+
+		now   func() time.Time
+		sleep func(time.Duration)
+	}
+)
+
+const (
+	DefaultMaxAttempts = 5
+	DefaultRetryDelay  = time.Millisecond * 200
+
+	// Limits of PutLogEvents.
+	MaxBatchEvents = 10000
+	MaxBatchSize   = 1048576
+	MaxEventSize   = 262144
+	EventOverhead  = 26
+)
+
+var (
+	ErrThrottled = errors.New("throttled")
+)
+
+func (e *SequenceTokenError) Error() string {
+	if e.Expected == nil {
+		return "invalid sequence token, expected none"
+	}
+	return fmt.Sprintf("invalid sequence token, expected %s", *e.Expected)
+}
+
+// NewDumper creates a Dumper putting every line of a dump as a separate event into Stream of Group, split into as few
+// PutLogEvents calls as their limits allow. Invalid UTF-8 in messages is replaced with U+FFFD, since CloudWatch rejects
+// it, and messages longer than an event may be are truncated at a character boundary. Calls failing with
+// ErrThrottled are retried up to MaxAttempts times in total, the delay between attempts starts with RetryDelay and
+// doubles, each attempt limited by Timeout if it is positive. A call with a rejected sequence token is retried with the
+// expected one. The Dumper implements alslgr.BatchDumper, so when used by a Logger every record written into it is a
+// separate event.
+func NewDumper(client Client, config Config) alslgr.Dumper {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = DefaultMaxAttempts
+	}
+	if config.RetryDelay <= 0 {
+		config.RetryDelay = DefaultRetryDelay
+	}
+
+	return &dumper{
+		client: client,
+		config: config,
+		now:    time.Now,
+		sleep:  time.Sleep,
+	}
+}
+
+func (d *dumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	timestamp := d.now()
+
+	d.events = d.events[:0]
+	for len(b) > 0 {
+		line := b
+		i := bytes.IndexByte(b, '\n')
+		if i >= 0 {
+			line, b = b[:i], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		d.appendEvent(timestamp, line)
+	}
+
+	return d.putEvents()
+}
+
+func (d *dumper) DumpMany(records [][]byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	timestamp := d.now()
+
+	d.events = d.events[:0]
+	for _, record := range records {
+		d.appendEvent(timestamp, bytes.TrimSuffix(record, []byte{'\n'}))
+	}
+
+	return d.putEvents()
+}
+
+func (d *dumper) appendEvent(timestamp time.Time, message []byte) {
+	if len(message) == 0 {
+		return
+	}
+
+	if !utf8.Valid(message) {
+		message = bytes.ToValidUTF8(message, []byte(string(utf8.RuneError)))
+	}
+
+	if n := MaxEventSize - EventOverhead; len(message) > n {
+		for n > 0 && !utf8.RuneStart(message[n]) {
+			n--
+		}
+		message = message[:n]
+	}
+
+	d.events = append(d.events, Event{
+		Timestamp: timestamp,
+		Message:   string(message),
+	})
+}
+
+// putEvents puts events in batches respecting limits of PutLogEvents.
+func (d *dumper) putEvents() error {
+	events := d.events
+	for len(events) > 0 {
+		n, size := 0, 0
+		for n < len(events) && n < MaxBatchEvents {
+			eventSize := len(events[n].Message) + EventOverhead
+			if size+eventSize > MaxBatchSize {
+				break
+			}
+			size += eventSize
+			n++
+		}
+
+		err := d.put(events[:n])
+		if err != nil {
+			return err
+		}
+		events = events[n:]
+	}
+
+	return nil
+}
+
+func (d *dumper) put(events []Event) error {
+	delay := d.config.RetryDelay
+
+	for attempt := 1; ; attempt++ {
+		err := d.putOnce(events)
+
+		var tokenErr *SequenceTokenError
+		if errors.As(err, &tokenErr) {
+			d.sequenceToken = tokenErr.Expected
+			err = d.putOnce(events)
+		}
+
+		if !errors.Is(err, ErrThrottled) || attempt >= d.config.MaxAttempts {
+			return err
+		}
+
+		d.sleep(delay)
+		delay *= 2
+	}
+}
+
+func (d *dumper) putOnce(events []Event) error {
+	ctx, cancel := d.context()
+	defer cancel()
+
+	token, err := d.client.PutLogEvents(ctx, d.config.Group, d.config.Stream, events, d.sequenceToken)
+	if err != nil {
+		return err
+	}
+
+	d.sequenceToken = token
+	return nil
+}
+
+func (d *dumper) context() (context.Context, context.CancelFunc) {
+	if d.config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), d.config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
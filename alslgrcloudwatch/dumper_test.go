@@ -0,0 +1,114 @@
+package alslgrcloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	TestClient struct {
+		token     int
+		throttles int
+		batches   []int
+		messages  []string
+	}
+)
+
+func (c *TestClient) PutLogEvents(_ context.Context, _, _ string, events []Event, sequenceToken *string) (*string, error) {
+	if c.throttles > 0 {
+		c.throttles--
+		return nil, fmt.Errorf("%w: slow down", ErrThrottled)
+	}
+
+	expected := strconv.Itoa(c.token)
+	if sequenceToken == nil || *sequenceToken != expected {
+		return nil, &SequenceTokenError{Expected: &expected}
+	}
+
+	c.batches = append(c.batches, len(events))
+	for _, e := range events {
+		c.messages = append(c.messages, e.Message)
+	}
+
+	c.token++
+	next := strconv.Itoa(c.token)
+	return &next, nil
+}
+
+func TestDumper(t *testing.T) {
+	c := &TestClient{token: 7, throttles: 2}
+	d := NewDumper(c, Config{Group: "group", Stream: "stream"})
+
+	var delays []time.Duration
+	d.(*dumper).sleep = func(d time.Duration) {
+		delays = append(delays, d)
+	}
+
+	err := d.Dump([]byte(strings.Repeat("A\n", MaxBatchEvents+1)))
+	if err != nil {
+		t.Errorf("TEST \"CLOUDWATCH DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedDelays := []time.Duration{DefaultRetryDelay, DefaultRetryDelay * 2}
+	if fmt.Sprint(c.batches) != fmt.Sprint([]int{MaxBatchEvents, 1}) || fmt.Sprint(delays) != fmt.Sprint(expectedDelays) {
+		t.Errorf("TEST \"CLOUDWATCH DUMPER\" FAILED: EXPECTED BATCHES %v AFTER DELAYS %v GOT %v AFTER %v\n",
+			[]int{MaxBatchEvents, 1}, expectedDelays, c.batches, delays)
+	}
+
+	c.throttles = DefaultMaxAttempts
+
+	err = d.Dump([]byte("B\n"))
+	if !errors.Is(err, ErrThrottled) {
+		t.Errorf("TEST \"CLOUDWATCH DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrThrottled, err)
+	}
+}
+
+func TestDumpMany(t *testing.T) {
+	c := &TestClient{}
+	d := NewDumper(c, Config{Group: "group", Stream: "stream"})
+
+	l := alslgr.NewLogger(1<<4, d)
+
+	for _, data := range []string{"A\n", "B"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"CLOUDWATCH DUMP MANY\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"CLOUDWATCH DUMP MANY\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expected := "[A B]"
+	given := fmt.Sprint(c.messages)
+	if given != expected || c.token != 1 {
+		t.Errorf("TEST \"CLOUDWATCH DUMP MANY\" FAILED: EXPECTED MESSAGES %s IN 1 CALL GOT %s IN %d\n", expected, given, c.token)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	c := &TestClient{}
+	d := NewDumper(c, Config{Group: "group", Stream: "stream"})
+
+	long := strings.Repeat("Ж", MaxEventSize)
+
+	err := d.Dump([]byte("A\xffB\n" + long))
+	if err != nil {
+		t.Errorf("TEST \"CLOUDWATCH TRUNCATE\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	if len(c.messages) != 2 || c.messages[0] != "A�B" || !utf8.ValidString(c.messages[1]) ||
+		len(c.messages[1]) > MaxEventSize-EventOverhead || !strings.HasPrefix(long, c.messages[1]) {
+		t.Errorf("TEST \"CLOUDWATCH TRUNCATE\" FAILED: EXPECTED VALID UTF-8 MESSAGES GOT %q\n", c.messages)
+	}
+}
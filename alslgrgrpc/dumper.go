@@ -0,0 +1,249 @@
+// Package alslgrgrpc provides an alslgr.Dumper streaming dumps to a collector service defined in collector.proto
+// through any client implementing Client, e.g. a thin adapter of the client generated from it. The proto file sets no
+// go_package, stubs are generated into a package of the importing module with protoc-gen-go and protoc-gen-go-grpc:
+//
+//	protoc --go_out=. --go_opt=Mcollector.proto=example.com/app/collectorpb \
+//		--go-grpc_out=. --go-grpc_opt=Mcollector.proto=example.com/app/collectorpb collector.proto
+//
+// The adapter of the generated client converts batches and acks:
+//
+//	type client struct{ collectorpb.CollectorClient }
+//
+//	func (c client) Ship(ctx context.Context) (alslgrgrpc.Stream, error) {
+//		s, err := c.CollectorClient.Ship(ctx)
+//		return stream{s}, err
+//	}
+//
+//	type stream struct {
+//		grpc.BidiStreamingClient[collectorpb.Batch, collectorpb.Ack]
+//	}
+//
+//	func (s stream) Send(b *alslgrgrpc.Batch) error {
+//		return s.BidiStreamingClient.Send(&collectorpb.Batch{Id: b.ID, Records: b.Records})
+//	}
+//
+//	func (s stream) Recv() (*alslgrgrpc.Ack, error) {
+//		a, err := s.BidiStreamingClient.Recv()
+//		if err != nil {
+//			return nil, err
+//		}
+//		return &alslgrgrpc.Ack{ID: a.Id, Error: a.Error}, nil
+//	}
+//
+// It is created with client{collectorpb.NewCollectorClient(conn)} from a grpc.ClientConn.
+package alslgrgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	Batch struct {
+		ID      uint64
+		Records [][]byte
+	}
+
+	Ack struct {
+		ID    uint64
+		Error string
+	}
+
+	// Client opens Collector.Ship streams.
+	Client interface {
+		Ship(ctx context.Context) (Stream, error)
+	}
+
+	// Stream is a Collector.Ship stream. Send must not retain the batch after returning.
+	Stream interface {
+		Send(batch *Batch) error
+		Recv() (*Ack, error)
+		CloseSend() error
+	}
+
+	Config struct {
+		AckTimeout time.Duration
+
+		MinReconnectDelay time.Duration
+		MaxReconnectDelay time.Duration
+	}
+
+	dumper struct {
+		mx sync.Mutex
+
+		client Client
+		config Config
+
+		stream         Stream
+		cancel         context.CancelFunc
+		id             uint64
+		reconnectDelay time.Duration
+		nextShip       time.Time
+		closed         bool
+
+		records [][]byte
+
+		now func() time.Time
+	}
+)
+
+const (
+	DefaultMinReconnectDelay = time.Millisecond * 100
+	DefaultMaxReconnectDelay = time.Second * 30
+)
+
+var (
+	ErrRejected   = errors.New("batch rejected by collector")
+	ErrAckMissing = errors.New("acknowledgement does not match batch")
+)
+
+// NewDumper creates a Dumper sending every dump as a batch over a stream opened by the client and waiting for its
+// acknowledgement, up to AckTimeout if positive. A rejected batch fails the dump with ErrRejected. A broken stream is
+// reopened once within the same dump, after that failed attempts are delayed exponentially from MinReconnectDelay to
+// MaxReconnectDelay, dumps fail with alslgr.ErrNotConnected meanwhile. The Dumper implements alslgr.BatchDumper, so
+// when used by a Logger every record written into it is a separate record of a batch. Dumps fail with
+// alslgr.ErrDumperClosed after Close.
+func NewDumper(client Client, config Config) alslgr.Dumper {
+	if config.MinReconnectDelay <= 0 {
+		config.MinReconnectDelay = DefaultMinReconnectDelay
+	}
+	if config.MaxReconnectDelay < config.MinReconnectDelay {
+		config.MaxReconnectDelay = DefaultMaxReconnectDelay
+	}
+
+	return &dumper{
+		client: client,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (d *dumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.records = append(d.records[:0], b)
+	err := d.ship(d.records)
+	d.records[0] = nil
+
+	return err
+}
+
+func (d *dumper) DumpMany(records [][]byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.ship(records)
+}
+
+func (d *dumper) ship(records [][]byte) error {
+	if d.closed {
+		return alslgr.ErrDumperClosed
+	}
+
+	reconnected := d.stream == nil
+
+	err := d.send(records)
+	if err == nil || reconnected || errors.Is(err, ErrRejected) {
+		return err
+	}
+
+	return d.send(records)
+}
+
+// send sends records as a batch and waits for the acknowledgement, the stream is closed on any error except rejection.
+func (d *dumper) send(records [][]byte) error {
+	if d.stream == nil {
+		err := d.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	d.id++
+	batch := &Batch{
+		ID:      d.id,
+		Records: records,
+	}
+
+	if d.config.AckTimeout > 0 {
+		timer := time.AfterFunc(d.config.AckTimeout, d.cancel)
+		defer timer.Stop()
+	}
+
+	err := d.stream.Send(batch)
+	if err != nil {
+		d.disconnect()
+		return err
+	}
+
+	ack, err := d.stream.Recv()
+	if err != nil {
+		d.disconnect()
+		return err
+	}
+
+	if ack.ID != batch.ID {
+		d.disconnect()
+		return fmt.Errorf("%w: expected %d got %d", ErrAckMissing, batch.ID, ack.ID)
+	}
+
+	if ack.Error != "" {
+		return fmt.Errorf("%w: %s", ErrRejected, ack.Error)
+	}
+
+	return nil
+}
+
+func (d *dumper) open() error {
+	now := d.now()
+	if now.Before(d.nextShip) {
+		return alslgr.ErrNotConnected
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	stream, err := d.client.Ship(ctx)
+	if err != nil {
+		cancel()
+
+		if d.reconnectDelay == 0 {
+			d.reconnectDelay = d.config.MinReconnectDelay
+		} else {
+			d.reconnectDelay = min(d.reconnectDelay*2, d.config.MaxReconnectDelay)
+		}
+		d.nextShip = now.Add(d.reconnectDelay)
+		return err
+	}
+
+	d.stream, d.cancel = stream, cancel
+	d.reconnectDelay = 0
+	return nil
+}
+
+func (d *dumper) disconnect() {
+	_ = d.stream.CloseSend()
+	d.cancel()
+	d.stream, d.cancel = nil, nil
+}
+
+func (d *dumper) Close() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.closed = true
+
+	if d.stream == nil {
+		return nil
+	}
+
+	err := d.stream.CloseSend()
+	d.cancel()
+	d.stream, d.cancel = nil, nil
+	return err
+}
@@ -0,0 +1,144 @@
+package alslgrgrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	TestClient struct {
+		ships     int
+		failShip  bool
+		breakSend bool
+		received  []string
+	}
+
+	TestStream struct {
+		client *TestClient
+		ctx    context.Context
+		last   *Batch
+	}
+)
+
+var (
+	errBroken = errors.New("BROKEN STREAM")
+)
+
+func (c *TestClient) Ship(ctx context.Context) (Stream, error) {
+	c.ships++
+	if c.failShip {
+		return nil, errBroken
+	}
+
+	return &TestStream{client: c, ctx: ctx}, nil
+}
+
+func (s *TestStream) Send(batch *Batch) error {
+	if s.client.breakSend {
+		s.client.breakSend = false
+		return errBroken
+	}
+	s.last = &Batch{ID: batch.ID, Records: append([][]byte(nil), batch.Records...)}
+	return nil
+}
+
+func (s *TestStream) Recv() (*Ack, error) {
+	if len(s.last.Records) > 0 && string(s.last.Records[0]) == "HANG" {
+		<-s.ctx.Done()
+		return nil, s.ctx.Err()
+	}
+
+	if len(s.last.Records) > 0 && string(s.last.Records[0]) == "REJECT" {
+		return &Ack{ID: s.last.ID, Error: "REJECTED"}, nil
+	}
+
+	s.client.received = append(s.client.received, fmt.Sprintf("%d:%q", s.last.ID, s.last.Records))
+	return &Ack{ID: s.last.ID}, nil
+}
+
+func (s *TestStream) CloseSend() error {
+	return nil
+}
+
+func TestDumper(t *testing.T) {
+	c := &TestClient{}
+	d := NewDumper(c, Config{AckTimeout: time.Millisecond * 10})
+
+	l := alslgr.NewLogger(1<<4, d)
+	for _, data := range []string{"A", "B"} {
+		_, _ = l.Write([]byte(data))
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	c.breakSend = true
+
+	err = d.Dump([]byte("C"))
+	if err != nil {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = d.Dump([]byte("REJECT"))
+	if !errors.Is(err, ErrRejected) {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrRejected, err)
+	}
+
+	err = d.Dump([]byte("HANG"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", context.Canceled, err)
+	}
+
+	expected := `[1:["A" "B"] 3:["C"]]`
+	given := fmt.Sprint(c.received)
+	if given != expected || c.ships != 3 {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED BATCHES %s WITH %d STREAMS GOT %s WITH %d\n", expected, 3, given, c.ships)
+	}
+
+	err = d.(io.Closer).Close()
+	if err != nil {
+		t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	for _, err = range []error{d.Dump([]byte("D")), d.(alslgr.BatchDumper).DumpMany([][]byte{[]byte("D")})} {
+		if !errors.Is(err, alslgr.ErrDumperClosed) {
+			t.Errorf("TEST \"GRPC DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", alslgr.ErrDumperClosed, err)
+		}
+	}
+}
+
+func TestDumperReconnectDelay(t *testing.T) {
+	c := &TestClient{failShip: true}
+	d := NewDumper(c, Config{MinReconnectDelay: time.Second}).(*dumper)
+
+	now := time.Now()
+	d.now = func() time.Time {
+		return now
+	}
+
+	err := d.Dump([]byte("A"))
+	if !errors.Is(err, errBroken) {
+		t.Errorf("TEST \"GRPC RECONNECT DELAY\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", errBroken, err)
+	}
+
+	err = d.Dump([]byte("A"))
+	if !errors.Is(err, alslgr.ErrNotConnected) {
+		t.Errorf("TEST \"GRPC RECONNECT DELAY\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", alslgr.ErrNotConnected, err)
+	}
+
+	c.failShip = false
+	now = now.Add(time.Second)
+
+	err = d.Dump([]byte("A"))
+	if err != nil || c.ships != 2 {
+		t.Errorf("TEST \"GRPC RECONNECT DELAY\" FAILED: EXPECTED DUMP ERROR \"nil\" AFTER %d STREAMS GOT \"%v\" AFTER %d\n", 2, err, c.ships)
+	}
+}
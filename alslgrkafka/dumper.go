@@ -0,0 +1,136 @@
+// Package alslgrkafka provides an alslgr.Dumper publishing dumps to Kafka through any client implementing Producer.
+package alslgrkafka
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	Message struct {
+		Topic string
+		Key   []byte
+		Value []byte
+	}
+
+	// Producer publishes messages synchronously, it must not retain them after returning.
+	Producer interface {
+		Produce(ctx context.Context, messages ...Message) error
+	}
+
+	Config struct {
+		Topic        string
+		Key          func(value []byte) []byte
+		SplitRecords bool
+		Delimiter    byte
+		Timeout      time.Duration
+	}
+
+	dumper struct {
+		mx sync.Mutex
+
+		producer Producer
+		config   Config
+
+		messages []Message
+		value    []byte
+	}
+)
+
+// NewDumper creates a Dumper publishing each dump as a single message to Topic, or each record of a dump as a separate
+// message if SplitRecords is set. Records are delimited by Delimiter, '\n' by default. Key, if not nil, computes the
+// partition key of a message from its value. The Dumper implements alslgr.BatchDumper, so when used by a Logger with
+// SplitRecords set every record written into it is published as a separate message, regardless of Delimiter.
+func NewDumper(producer Producer, config Config) alslgr.Dumper {
+	if config.Delimiter == 0 {
+		config.Delimiter = '\n'
+	}
+
+	return &dumper{
+		producer: producer,
+		config:   config,
+	}
+}
+
+func (d *dumper) Dump(b []byte) error {
+	if !d.config.SplitRecords {
+		ctx, cancel := d.context()
+		defer cancel()
+
+		return d.producer.Produce(ctx, d.message(b))
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	messages := d.messages[:0]
+	for len(b) > 0 {
+		record := b
+		i := bytes.IndexByte(b, d.config.Delimiter)
+		if i >= 0 {
+			record, b = b[:i+1], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		messages = append(messages, d.message(record))
+	}
+	d.messages = messages
+
+	return d.produce()
+}
+
+func (d *dumper) message(value []byte) Message {
+	m := Message{
+		Topic: d.config.Topic,
+		Value: value,
+	}
+	if d.config.Key != nil {
+		m.Key = d.config.Key(value)
+	}
+	return m
+}
+
+func (d *dumper) DumpMany(records [][]byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if !d.config.SplitRecords {
+		d.value = d.value[:0]
+		for _, record := range records {
+			d.value = append(d.value, record...)
+		}
+
+		d.messages = append(d.messages[:0], d.message(d.value))
+		return d.produce()
+	}
+
+	messages := d.messages[:0]
+	for _, record := range records {
+		messages = append(messages, d.message(record))
+	}
+	d.messages = messages
+
+	return d.produce()
+}
+
+// produce publishes the messages and clears them, so they do not keep dumped data referenced.
+func (d *dumper) produce() error {
+	ctx, cancel := d.context()
+	defer cancel()
+
+	err := d.producer.Produce(ctx, d.messages...)
+	clear(d.messages)
+	return err
+}
+
+func (d *dumper) context() (context.Context, context.CancelFunc) {
+	if d.config.Timeout > 0 {
+		return context.WithTimeout(context.Background(), d.config.Timeout)
+	}
+	return context.WithCancel(context.Background())
+}
@@ -0,0 +1,140 @@
+package alslgrkafka
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	TestProducer struct {
+		produced []string
+	}
+)
+
+func (p *TestProducer) Produce(_ context.Context, messages ...Message) error {
+	for _, m := range messages {
+		p.produced = append(p.produced, fmt.Sprintf("%s/%s/%q", m.Topic, m.Key, m.Value))
+	}
+	return nil
+}
+
+func TestDumper(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Split    bool
+		Expected string
+	}{
+		{Name: "WHOLE DUMP", Split: false, Expected: `[logs/A/"A\nB\n"]`},
+		{Name: "SPLIT RECORDS", Split: true, Expected: `[logs/A/"A\n" logs/B/"B\n"]`},
+	}
+
+	for _, test := range tests {
+		p := &TestProducer{}
+		d := NewDumper(p, Config{
+			Topic:        "logs",
+			SplitRecords: test.Split,
+			Key: func(value []byte) []byte {
+				return value[:1]
+			},
+		})
+
+		err := d.Dump([]byte("A\nB\n"))
+		if err != nil {
+			t.Errorf("TEST \"KAFKA %s\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		given := fmt.Sprint(p.produced)
+		if given != test.Expected {
+			t.Errorf("TEST \"KAFKA %s\" FAILED: EXPECTED MESSAGES %s GOT %s\n", test.Name, test.Expected, given)
+		}
+	}
+}
+
+func TestDumpMany(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Split    bool
+		Expected string
+	}{
+		{Name: "WHOLE DUMP", Split: false, Expected: `[logs//"AB"]`},
+		{Name: "SPLIT RECORDS", Split: true, Expected: `[logs//"A" logs//"B"]`},
+	}
+
+	for _, test := range tests {
+		p := &TestProducer{}
+		d := NewDumper(p, Config{Topic: "logs", SplitRecords: test.Split})
+
+		l := alslgr.NewLogger(1<<4, d)
+
+		for _, data := range []string{"A", "B"} {
+			_, err := l.Write([]byte(data))
+			if err != nil {
+				t.Errorf("TEST \"KAFKA DUMP MANY %s\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+			}
+		}
+
+		err := l.Close()
+		if err != nil {
+			t.Errorf("TEST \"KAFKA DUMP MANY %s\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		given := fmt.Sprint(p.produced)
+		if given != test.Expected {
+			t.Errorf("TEST \"KAFKA DUMP MANY %s\" FAILED: EXPECTED MESSAGES %s GOT %s\n", test.Name, test.Expected, given)
+		}
+	}
+}
+
+type (
+	BatchCheckingProducer struct {
+		mx    sync.Mutex
+		mixed bool
+	}
+)
+
+func (p *BatchCheckingProducer) Produce(_ context.Context, messages ...Message) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	for _, m := range messages {
+		if !bytes.Equal(m.Value, messages[0].Value) {
+			p.mixed = true
+		}
+	}
+	return nil
+}
+
+func TestConcurrentDumps(t *testing.T) {
+	p := &BatchCheckingProducer{}
+	d := NewDumper(p, Config{Topic: "logs", SplitRecords: true})
+
+	var wg sync.WaitGroup
+	for _, data := range []string{"A\n", "B\n", "C\n", "D\n"} {
+		wg.Add(1)
+		go func(record []byte) {
+			defer wg.Done()
+
+			for i := 0; i < 1<<10; i++ {
+				_ = d.(alslgr.BatchDumper).DumpMany([][]byte{record, record, record})
+				_ = d.Dump(bytes.Repeat(record, 3))
+			}
+		}([]byte(data))
+	}
+	wg.Wait()
+
+	if p.mixed {
+		t.Errorf("TEST \"KAFKA CONCURRENT DUMPS\" FAILED: EXPECTED MESSAGES OF ONE DUMP PER BATCH GOT MIXED\n")
+	}
+
+	for _, m := range d.(*dumper).messages {
+		if m.Value != nil {
+			t.Errorf("TEST \"KAFKA CONCURRENT DUMPS\" FAILED: EXPECTED PRODUCED MESSAGES CLEARED GOT %q\n", m.Value)
+			break
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package alslgrprometheus
+
+import (
+	"sync/atomic"
+
+	"github.com/alsiberij/alslgr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type (
+	// Collector is a prometheus.Collector exposing metrics of a Logger it is passed to with alslgr.WithMetricsRecorder.
+	// Watch makes it expose the fill ratio of the buffer of the logger as well.
+	Collector interface {
+		prometheus.Collector
+		alslgr.MetricsRecorder
+
+		Watch(logger alslgr.Logger)
+	}
+
+	// CollectorOpts define names and labels of metrics of a Collector, which are prefixed with Namespace and Subsystem,
+	// "alslgr" by default. DurationBuckets are buckets of the dump duration histogram, prometheus.DefBuckets by default.
+	CollectorOpts struct {
+		Namespace       string
+		Subsystem       string
+		ConstLabels     prometheus.Labels
+		DurationBuckets []float64
+	}
+
+	collector struct {
+		recorder
+
+		logger atomic.Pointer[alslgr.Logger]
+
+		collectors []prometheus.Collector
+	}
+)
+
+const (
+	CollectorDefaultSubsystem = "alslgr"
+)
+
+// NewCollector creates a Collector ready to be registered, exposing the fill ratio of the buffer, the number of
+// buffered, dumped and dropped records and bytes, the number of dumps and failed dumps and the histogram of dump
+// durations in seconds.
+//
+//	collector := alslgrprometheus.NewCollector(alslgrprometheus.CollectorOpts{Namespace: "app"})
+//	logger := alslgr.NewLogger(capacity, dumper, alslgr.WithMetricsRecorder(collector))
+//	collector.Watch(logger)
+//	prometheus.MustRegister(collector)
+func NewCollector(opts CollectorOpts) Collector {
+	if opts.Subsystem == "" {
+		opts.Subsystem = CollectorDefaultSubsystem
+	}
+	if len(opts.DurationBuckets) == 0 {
+		opts.DurationBuckets = prometheus.DefBuckets
+	}
+
+	c := &collector{}
+
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace:   opts.Namespace,
+			Subsystem:   opts.Subsystem,
+			Name:        name,
+			Help:        help,
+			ConstLabels: opts.ConstLabels,
+		})
+		c.collectors = append(c.collectors, counter)
+		return counter
+	}
+
+	c.metrics = Metrics{
+		BufferedRecords: counter("buffered_records_total", "Number of records written into the buffer."),
+		BufferedBytes:   counter("buffered_bytes_total", "Number of bytes written into the buffer."),
+		Dumps:           counter("dumps_total", "Number of dumps."),
+		DumpErrors:      counter("dump_errors_total", "Number of failed dumps."),
+		DumpedBytes:     counter("dumped_bytes_total", "Number of bytes dumped successfully."),
+		DroppedRecords:  counter("dropped_records_total", "Number of records discarded without being dumped."),
+		DroppedBytes:    counter("dropped_bytes_total", "Number of bytes discarded without being dumped."),
+	}
+
+	dumpDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace:   opts.Namespace,
+		Subsystem:   opts.Subsystem,
+		Name:        "dump_duration_seconds",
+		Help:        "Duration of dumps.",
+		ConstLabels: opts.ConstLabels,
+		Buckets:     opts.DurationBuckets,
+	})
+	c.metrics.DumpDuration = dumpDuration
+
+	fillRatio := prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   opts.Namespace,
+		Subsystem:   opts.Subsystem,
+		Name:        "buffer_fill_ratio",
+		Help:        "Share of the capacity of the buffer occupied by buffered data.",
+		ConstLabels: opts.ConstLabels,
+	}, c.fillRatio)
+
+	c.collectors = append(c.collectors, dumpDuration, fillRatio)
+
+	return c
+}
+
+func (c *collector) Watch(logger alslgr.Logger) {
+	c.logger.Store(&logger)
+}
+
+func (c *collector) fillRatio() float64 {
+	logger := c.logger.Load()
+	if logger == nil {
+		return 0
+	}
+	return FillRatio(*logger)()
+}
+
+func (c *collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, collector := range c.collectors {
+		collector.Describe(ch)
+	}
+}
+
+func (c *collector) Collect(ch chan<- prometheus.Metric) {
+	for _, collector := range c.collectors {
+		collector.Collect(ch)
+	}
+}
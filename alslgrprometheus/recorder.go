@@ -0,0 +1,95 @@
+// Package alslgrprometheus exports metrics of an alslgr.Logger to Prometheus. NewCollector creates a collector ready to
+// be registered, while NewRecorder updates metrics created elsewhere, e.g. to add labels or share them between loggers:
+// counters, histograms and gauge functions of the client library fit the types below as they are.
+//
+//	dumpErrors := prometheus.NewCounter(prometheus.CounterOpts{Name: "log_dump_errors_total"})
+//	dumpDuration := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "log_dump_duration_seconds"})
+//	logger := alslgr.NewLogger(capacity, dumper, alslgr.WithMetricsRecorder(alslgrprometheus.NewRecorder(
+//		alslgrprometheus.Metrics{DumpErrors: dumpErrors, DumpDuration: dumpDuration},
+//	)))
+//	fillRatio := prometheus.NewGaugeFunc(prometheus.GaugeOpts{Name: "log_buffer_fill_ratio"},
+//		alslgrprometheus.FillRatio(logger))
+//	prometheus.MustRegister(dumpErrors, dumpDuration, fillRatio)
+package alslgrprometheus
+
+import (
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	Counter interface {
+		Add(v float64)
+	}
+
+	Observer interface {
+		Observe(v float64)
+	}
+
+	// Metrics holds metrics updated by a recorder, nil ones are skipped. DumpDuration observes seconds.
+	Metrics struct {
+		BufferedRecords Counter
+		BufferedBytes   Counter
+
+		Dumps        Counter
+		DumpErrors   Counter
+		DumpedBytes  Counter
+		DumpDuration Observer
+
+		DroppedRecords Counter
+		DroppedBytes   Counter
+	}
+
+	recorder struct {
+		metrics Metrics
+	}
+)
+
+// NewRecorder creates an alslgr.MetricsRecorder updating metrics, to be passed to alslgr.WithMetricsRecorder.
+func NewRecorder(metrics Metrics) alslgr.MetricsRecorder {
+	return &recorder{
+		metrics: metrics,
+	}
+}
+
+// FillRatio returns a function reporting the share of the capacity of logger occupied by buffered data, to be passed
+// to prometheus.NewGaugeFunc.
+func FillRatio(logger alslgr.Logger) func() float64 {
+	return func() float64 {
+		capacity := logger.Cap()
+		if capacity <= 0 {
+			return 0
+		}
+		return float64(logger.PendingBytes()) / float64(capacity)
+	}
+}
+
+func (r *recorder) RecordBuffered(bytes int) {
+	add(r.metrics.BufferedRecords, 1)
+	add(r.metrics.BufferedBytes, bytes)
+}
+
+func (r *recorder) RecordDump(bytes int, duration time.Duration, err error) {
+	add(r.metrics.Dumps, 1)
+	if err != nil {
+		add(r.metrics.DumpErrors, 1)
+	} else {
+		add(r.metrics.DumpedBytes, bytes)
+	}
+
+	if r.metrics.DumpDuration != nil {
+		r.metrics.DumpDuration.Observe(duration.Seconds())
+	}
+}
+
+func (r *recorder) RecordDropped(records, bytes int) {
+	add(r.metrics.DroppedRecords, records)
+	add(r.metrics.DroppedBytes, bytes)
+}
+
+func add(c Counter, v int) {
+	if c != nil {
+		c.Add(float64(v))
+	}
+}
@@ -0,0 +1,108 @@
+package alslgrprometheus
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alsiberij/alslgr"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type (
+	TestCounter float64
+
+	TestObserver []float64
+
+	TestDumper bytes.Buffer
+)
+
+func (c *TestCounter) Add(v float64) {
+	*c += TestCounter(v)
+}
+
+func (o *TestObserver) Observe(v float64) {
+	*o = append(*o, v)
+}
+
+func (d *TestDumper) Dump(b []byte) error {
+	if string(b) == "FORCED ERROR" {
+		return errors.New("FORCED ERROR")
+	}
+	_, err := (*bytes.Buffer)(d).Write(b)
+	return err
+}
+
+func TestRecorder(t *testing.T) {
+	var bufferedBytes, dumpErrors, dumpedBytes, droppedRecords TestCounter
+	var dumpDuration TestObserver
+
+	l := alslgr.NewLogger(4, &TestDumper{}, alslgr.WithOverflowPolicy(alslgr.OverflowDropNewest),
+		alslgr.WithMetricsRecorder(NewRecorder(Metrics{
+			BufferedBytes:  &bufferedBytes,
+			DumpErrors:     &dumpErrors,
+			DumpedBytes:    &dumpedBytes,
+			DumpDuration:   &dumpDuration,
+			DroppedRecords: &droppedRecords,
+		})))
+	fillRatio := FillRatio(l)
+
+	_, _ = l.Write([]byte("AB"))
+	_, _ = l.Write([]byte("CDE"))
+
+	if fillRatio() != 0.5 {
+		t.Errorf("TEST \"PROMETHEUS RECORDER\" FAILED: EXPECTED FILL RATIO %v GOT %v\n", 0.5, fillRatio())
+	}
+
+	_ = l.DumpBuffer()
+
+	if bufferedBytes != 2 || dumpedBytes != 2 || droppedRecords != 1 || dumpErrors != 0 || len(dumpDuration) != 1 {
+		t.Errorf("TEST \"PROMETHEUS RECORDER\" FAILED: EXPECTED %v BUFFERED, %v DUMPED, %v DROPPED, %v ERRORS, %v DURATIONS "+
+			"GOT %v, %v, %v, %v, %v\n", 2, 2, 1, 0, 1, bufferedBytes, dumpedBytes, droppedRecords, dumpErrors, len(dumpDuration))
+	}
+}
+
+func TestCollector(t *testing.T) {
+	c := NewCollector(CollectorOpts{Namespace: "app", ConstLabels: prometheus.Labels{"logger": "test"}})
+
+	l := alslgr.NewLogger(4, &TestDumper{}, alslgr.WithOverflowPolicy(alslgr.OverflowDropNewest),
+		alslgr.WithMetricsRecorder(c))
+	c.Watch(l)
+
+	_, _ = l.Write([]byte("AB"))
+	_, _ = l.Write([]byte("CDE"))
+
+	registry := prometheus.NewPedanticRegistry()
+	err := registry.Register(c)
+	if err != nil {
+		t.Fatalf("TEST \"PROMETHEUS COLLECTOR\" FAILED: EXPECTED REGISTER ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expected := `
+# HELP app_alslgr_buffer_fill_ratio Share of the capacity of the buffer occupied by buffered data.
+# TYPE app_alslgr_buffer_fill_ratio gauge
+app_alslgr_buffer_fill_ratio{logger="test"} 0.5
+# HELP app_alslgr_dropped_records_total Number of records discarded without being dumped.
+# TYPE app_alslgr_dropped_records_total counter
+app_alslgr_dropped_records_total{logger="test"} 1
+`
+	err = testutil.GatherAndCompare(registry, strings.NewReader(expected),
+		"app_alslgr_buffer_fill_ratio", "app_alslgr_dropped_records_total")
+	if err != nil {
+		t.Errorf("TEST \"PROMETHEUS COLLECTOR\" FAILED: EXPECTED METRICS GOT \"%v\"\n", err)
+	}
+
+	_ = l.DumpBuffer()
+
+	count, err := testutil.GatherAndCount(registry, "app_alslgr_dump_duration_seconds", "app_alslgr_dumped_bytes_total")
+	if err != nil || count != 2 {
+		t.Errorf("TEST \"PROMETHEUS COLLECTOR\" FAILED: EXPECTED %d DUMP METRICS GOT %d \"%v\"\n", 2, count, err)
+	}
+
+	if testutil.ToFloat64(c.(*collector).metrics.DumpedBytes.(prometheus.Counter)) != 2 {
+		t.Errorf("TEST \"PROMETHEUS COLLECTOR\" FAILED: EXPECTED %d DUMPED BYTES GOT %v\n", 2,
+			testutil.ToFloat64(c.(*collector).metrics.DumpedBytes.(prometheus.Counter)))
+	}
+}
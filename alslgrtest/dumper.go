@@ -0,0 +1,193 @@
+// Package alslgrtest provides an in-memory alslgr.Dumper recording dumps, with scripted failures and latency, and
+// assertion helpers for tests of code using alslgr.
+package alslgrtest
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+type (
+	// MockDumper is a race-safe alslgr.Dumper keeping a copy of every successful dump.
+	MockDumper interface {
+		alslgr.Dumper
+
+		// Dumps returns copies of successful dumps in the order they have been made.
+		Dumps() [][]byte
+		// Data returns successful dumps concatenated.
+		Data() []byte
+		// Calls returns the number of Dump calls, failed ones included.
+		Calls() int
+		// Wait waits until at least n dumps have succeeded and reports whether they have within timeout.
+		Wait(n int, timeout time.Duration) bool
+		Reset()
+	}
+
+	Option func(d *mockDumper)
+
+	mockDumper struct {
+		mx   sync.Mutex
+		cond *sync.Cond
+
+		dumps [][]byte
+		calls int
+
+		failFirst int
+		failMatch func(b []byte) bool
+		err       error
+		latency   time.Duration
+	}
+)
+
+var (
+	ErrScripted = errors.New("alslgrtest: scripted failure")
+)
+
+// WithFailFirst makes the first n Dump calls fail.
+func WithFailFirst(n int) Option {
+	return func(d *mockDumper) {
+		d.failFirst = n
+	}
+}
+
+// WithFailMatching makes Dump fail for every payload match returns true for.
+func WithFailMatching(match func(b []byte) bool) Option {
+	return func(d *mockDumper) {
+		d.failMatch = match
+	}
+}
+
+// WithError makes scripted failures return err instead of ErrScripted.
+func WithError(err error) Option {
+	return func(d *mockDumper) {
+		d.err = err
+	}
+}
+
+// WithLatency makes every Dump call take at least latency.
+func WithLatency(latency time.Duration) Option {
+	return func(d *mockDumper) {
+		d.latency = latency
+	}
+}
+
+func NewMockDumper(opts ...Option) MockDumper {
+	d := &mockDumper{
+		err: ErrScripted,
+	}
+	d.cond = sync.NewCond(&d.mx)
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+func (d *mockDumper) Dump(b []byte) error {
+	if d.latency > 0 {
+		time.Sleep(d.latency)
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.calls++
+	if d.calls <= d.failFirst || (d.failMatch != nil && d.failMatch(b)) {
+		return d.err
+	}
+
+	d.dumps = append(d.dumps, bytes.Clone(b))
+	d.cond.Broadcast()
+
+	return nil
+}
+
+func (d *mockDumper) Dumps() [][]byte {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	dumps := make([][]byte, len(d.dumps))
+	for i, b := range d.dumps {
+		dumps[i] = bytes.Clone(b)
+	}
+	return dumps
+}
+
+func (d *mockDumper) Data() []byte {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return bytes.Join(d.dumps, nil)
+}
+
+func (d *mockDumper) Calls() int {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.calls
+}
+
+func (d *mockDumper) Wait(n int, timeout time.Duration) bool {
+	timer := time.AfterFunc(timeout, func() {
+		d.mx.Lock()
+		defer d.mx.Unlock()
+
+		d.cond.Broadcast()
+	})
+	defer timer.Stop()
+
+	deadline := time.Now().Add(timeout)
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	for len(d.dumps) < n && time.Now().Before(deadline) {
+		d.cond.Wait()
+	}
+
+	return len(d.dumps) >= n
+}
+
+func (d *mockDumper) Reset() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.dumps = nil
+	d.calls = 0
+}
+
+// AssertData reports an error to t unless successful dumps of d concatenated are equal to expected.
+func AssertData(t testing.TB, d MockDumper, expected string) {
+	t.Helper()
+
+	given := string(d.Data())
+	if given != expected {
+		t.Errorf("ASSERTION FAILED: EXPECTED DATA %q GOT %q\n", expected, given)
+	}
+}
+
+// AssertDumps reports an error to t unless d has succeeded exactly n times.
+func AssertDumps(t testing.TB, d MockDumper, n int) {
+	t.Helper()
+
+	given := len(d.Dumps())
+	if given != n {
+		t.Errorf("ASSERTION FAILED: EXPECTED %d DUMPS GOT %d\n", n, given)
+	}
+}
+
+// AssertCalls reports an error to t unless Dump of d has been called exactly n times.
+func AssertCalls(t testing.TB, d MockDumper, n int) {
+	t.Helper()
+
+	given := d.Calls()
+	if given != n {
+		t.Errorf("ASSERTION FAILED: EXPECTED %d DUMP CALLS GOT %d\n", n, given)
+	}
+}
@@ -0,0 +1,60 @@
+package alslgrtest
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alsiberij/alslgr"
+)
+
+func TestMockDumper(t *testing.T) {
+	d := NewMockDumper(WithFailFirst(1), WithFailMatching(func(b []byte) bool {
+		return bytes.Contains(b, []byte("X"))
+	}))
+
+	l := alslgr.NewLogger(1<<4, d)
+
+	_, _ = l.Write([]byte("A"))
+	err := l.DumpBuffer()
+	if !errors.Is(err, ErrScripted) {
+		t.Errorf("TEST \"MOCK DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrScripted, err)
+	}
+
+	_, _ = l.Write([]byte("B"))
+	err = l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"MOCK DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = d.Dump([]byte("X"))
+	if !errors.Is(err, ErrScripted) {
+		t.Errorf("TEST \"MOCK DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrScripted, err)
+	}
+
+	AssertData(t, d, "AB")
+	AssertDumps(t, d, 2)
+	AssertCalls(t, d, 4)
+}
+
+func TestMockDumperWait(t *testing.T) {
+	d := NewMockDumper(WithLatency(time.Millisecond))
+
+	l := alslgr.NewLogger(1<<4, d)
+	_, cancel := l.AutoDumpBuffer(time.Millisecond)
+	defer cancel()
+
+	_, _ = l.Write([]byte("A"))
+
+	if !d.Wait(1, time.Second) {
+		t.Errorf("TEST \"MOCK DUMPER WAIT\" FAILED: EXPECTED DUMP WITHIN %v\n", time.Second)
+	}
+
+	if d.Wait(5, time.Millisecond) {
+		t.Errorf("TEST \"MOCK DUMPER WAIT\" FAILED: EXPECTED WAIT TO TIME OUT\n")
+	}
+
+	d.Reset()
+	AssertCalls(t, d, 0)
+}
@@ -0,0 +1,46 @@
+package alslgr
+
+import (
+	"sync/atomic"
+)
+
+type (
+	budget struct {
+		limit int64
+		used  atomic.Int64
+	}
+)
+
+// NewBudget creates a Budget limiting data buffered by all loggers sharing it, e.g. ones registered in a Manager, to
+// limit bytes.
+func NewBudget(limit int) Budget {
+	return &budget{
+		limit: int64(limit),
+	}
+}
+
+func (b *budget) Used() int {
+	return int(b.used.Load())
+}
+
+func (b *budget) Limit() int {
+	return int(b.limit)
+}
+
+// reserve accounts n bytes unless the limit would be exceeded.
+func (b *budget) reserve(n int) bool {
+	for {
+		used := b.used.Load()
+		if used+int64(n) > b.limit {
+			return false
+		}
+		if b.used.CompareAndSwap(used, used+int64(n)) {
+			return true
+		}
+	}
+}
+
+// add accounts n bytes regardless of the limit, negative n releases bytes.
+func (b *budget) add(n int) {
+	b.used.Add(int64(n))
+}
@@ -0,0 +1,206 @@
+package alslgr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	buffer[T any] struct {
+		mx     sync.Mutex
+		dumpMx sync.Mutex
+
+		active   []T
+		spare    []T
+		capacity int
+
+		dumper RecordDumper[T]
+
+		ctx     context.Context
+		cancel  context.CancelFunc
+		workers sync.WaitGroup
+		closed  atomic.Bool
+	}
+
+	// RecordDumperFunc is a RecordDumper calling the function.
+	RecordDumperFunc[T any] func(records []T) error
+
+	serializingDumper[T any] struct {
+		mx sync.Mutex
+
+		serialize func(dst []byte, record T) ([]byte, error)
+		dumper    Dumper
+		payload   []byte
+	}
+)
+
+// NewBuffer creates a Buffer of records of type T holding up to capacity records. Like the Logger, it dumps records
+// through a spare buffer, so writers are blocked only while buffers are swapped, and retains records failed to be
+// dumped to retry them first.
+func NewBuffer[T any](capacity int, dumper RecordDumper[T]) Buffer[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	capacity = max(capacity, 1)
+
+	return &buffer[T]{
+		active:   make([]T, 0, capacity),
+		spare:    make([]T, 0, capacity),
+		capacity: capacity,
+		dumper:   dumper,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+func (b *buffer[T]) Write(record T) error {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	for {
+		if b.closed.Load() {
+			return ErrLoggerClosed
+		}
+
+		if len(b.active) < b.capacity {
+			b.active = append(b.active, record)
+			return nil
+		}
+
+		b.mx.Unlock()
+		err := b.dump(context.Background())
+		b.mx.Lock()
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (b *buffer[T]) DumpBuffer() error {
+	return b.DumpBufferContext(context.Background())
+}
+
+func (b *buffer[T]) DumpBufferContext(ctx context.Context) error {
+	if b.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	return b.dump(ctx)
+}
+
+func (b *buffer[T]) dump(ctx context.Context) error {
+	b.dumpMx.Lock()
+	defer b.dumpMx.Unlock()
+
+	err := b.dumpSpare(ctx)
+	if err != nil {
+		return err
+	}
+
+	b.mx.Lock()
+	b.active, b.spare = b.spare, b.active
+	b.mx.Unlock()
+
+	return b.dumpSpare(ctx)
+}
+
+func (b *buffer[T]) dumpSpare(ctx context.Context) error {
+	if len(b.spare) == 0 {
+		return nil
+	}
+
+	err := ctx.Err()
+	if err == nil {
+		err = b.dumper.DumpRecords(b.spare)
+	}
+	if err != nil {
+		return err
+	}
+
+	clear(b.spare)
+	b.spare = b.spare[:0]
+
+	return nil
+}
+
+func (b *buffer[T]) Len() int {
+	b.mx.Lock()
+	defer b.mx.Unlock()
+
+	return len(b.active)
+}
+
+func (b *buffer[T]) AutoDumpBuffer(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(b.ctx)
+	errCh := make(chan error, 1)
+
+	b.workers.Add(1)
+	go func() {
+		defer b.workers.Done()
+		repeatOpWorker(ctx, newSchedule(interval, opts), errCh, func() error {
+			return b.DumpBufferContext(ctx)
+		})
+	}()
+
+	return errCh, cancel
+}
+
+func (b *buffer[T]) Close() error {
+	if !b.closed.CompareAndSwap(false, true) {
+		return ErrLoggerClosed
+	}
+
+	b.cancel()
+	b.workers.Wait()
+
+	err := b.dump(context.Background())
+
+	closer, ok := b.dumper.(io.Closer)
+	if ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
+
+func (f RecordDumperFunc[T]) DumpRecords(records []T) error {
+	return f(records)
+}
+
+// NewSerializingDumper creates a RecordDumper appending every record to a payload with serialize and dumping the
+// payload with dumper, so records are serialized only when they are dumped.
+func NewSerializingDumper[T any](serialize func(dst []byte, record T) ([]byte, error), dumper Dumper) RecordDumper[T] {
+	return &serializingDumper[T]{
+		serialize: serialize,
+		dumper:    dumper,
+	}
+}
+
+func (d *serializingDumper[T]) DumpRecords(records []T) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	var err error
+
+	d.payload = d.payload[:0]
+	for _, record := range records {
+		d.payload, err = d.serialize(d.payload, record)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.dumper.Dump(d.payload)
+}
+
+func (d *serializingDumper[T]) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *serializingDumper[T]) Close() error {
+	return closeDumpers([]Dumper{d.dumper})
+}
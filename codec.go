@@ -0,0 +1,63 @@
+package alslgr
+
+import (
+	"compress/gzip"
+	"sync"
+)
+
+type (
+	gzipCodec struct {
+		writers sync.Pool
+	}
+
+	// appendWriter is an io.Writer appending to a slice.
+	appendWriter struct {
+		b []byte
+	}
+)
+
+// NewGzipCodec creates a Codec compressing payloads with gzip at the given level, which must be valid for
+// gzip.NewWriterLevel.
+func NewGzipCodec(level int) (Codec, error) {
+	_, err := gzip.NewWriterLevel(nil, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return newGzipCodec(level), nil
+}
+
+// newGzipCodec creates a gzip codec at level, which is known to be valid.
+func newGzipCodec(level int) *gzipCodec {
+	c := &gzipCodec{}
+	c.writers.New = func() any {
+		zw, _ := gzip.NewWriterLevel(nil, level)
+		return zw
+	}
+
+	return c
+}
+
+func (c *gzipCodec) Encode(dst, src []byte) ([]byte, error) {
+	zw, _ := c.writers.Get().(*gzip.Writer)
+	defer c.writers.Put(zw)
+
+	w := &appendWriter{b: dst}
+	zw.Reset(w)
+
+	_, err := zw.Write(src)
+	if err == nil {
+		err = zw.Close()
+	}
+
+	return w.b, err
+}
+
+func (c *gzipCodec) ContentEncoding() string {
+	return "gzip"
+}
+
+func (w *appendWriter) Write(b []byte) (int, error) {
+	w.b = append(w.b, b...)
+	return len(b), nil
+}
@@ -0,0 +1,46 @@
+package alslgr
+
+import (
+	"sync"
+)
+
+type (
+	compressingDumper struct {
+		mx sync.Mutex
+
+		dumper Dumper
+		codec  Codec
+		buf    []byte
+	}
+)
+
+// NewCompressingDumper creates a Dumper that encodes every payload with codec before passing it to dumper. The buffer
+// of the encoded payload is reused, so dumper must not retain it. Dumpers recording the encoding of a payload, like the
+// ones of NewHTTPDumper and NewObjectStoreDumper, take the Codec in their config instead.
+func NewCompressingDumper(dumper Dumper, codec Codec) Dumper {
+	return &compressingDumper{
+		dumper: dumper,
+		codec:  codec,
+	}
+}
+
+func (d *compressingDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	var err error
+	d.buf, err = d.codec.Encode(d.buf[:0], b)
+	if err != nil {
+		return err
+	}
+
+	return d.dumper.Dump(d.buf)
+}
+
+func (d *compressingDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *compressingDumper) Close() error {
+	return closeDumpers([]Dumper{d.dumper})
+}
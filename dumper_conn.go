@@ -0,0 +1,196 @@
+package alslgr
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	ConnDumperConfig struct {
+		Network string
+		Address string
+
+		DialTimeout  time.Duration
+		WriteTimeout time.Duration
+
+		MinReconnectDelay time.Duration
+		MaxReconnectDelay time.Duration
+
+		MaxDatagramSize int
+	}
+
+	connDumper struct {
+		mx sync.Mutex
+
+		config ConnDumperConfig
+		dialer net.Dialer
+		packet bool
+
+		conn           net.Conn
+		reconnectDelay time.Duration
+		nextDial       time.Time
+		closed         bool
+
+		now func() time.Time
+	}
+)
+
+const (
+	ConnDumperDefaultMinReconnectDelay = time.Millisecond * 100
+	ConnDumperDefaultMaxReconnectDelay = time.Second * 30
+
+	// ConnDumperDefaultMaxDatagramSize is the largest payload of a UDP datagram over IPv4.
+	ConnDumperDefaultMaxDatagramSize = 65507
+)
+
+// NewConnDumper creates a Dumper writing into a connection dialed with net.Dial semantics, e.g. "tcp", "udp" or
+// "unix" networks. A broken connection is re-dialed once within the same dump, after that failed dials are delayed
+// exponentially from MinReconnectDelay to MaxReconnectDelay, dumps fail with ErrNotConnected meanwhile. Over packet
+// networks, e.g. "udp" or "unixgram", a dump is split into datagrams of up to MaxDatagramSize bytes,
+// ConnDumperDefaultMaxDatagramSize by default, at line boundaries unless a line is longer. Dumps fail with
+// ErrDumperClosed after Close.
+func NewConnDumper(config ConnDumperConfig) Dumper {
+	if config.MinReconnectDelay <= 0 {
+		config.MinReconnectDelay = ConnDumperDefaultMinReconnectDelay
+	}
+	if config.MaxReconnectDelay < config.MinReconnectDelay {
+		config.MaxReconnectDelay = ConnDumperDefaultMaxReconnectDelay
+	}
+	if config.MaxDatagramSize <= 0 {
+		config.MaxDatagramSize = ConnDumperDefaultMaxDatagramSize
+	}
+
+	return &connDumper{
+		config: config,
+		dialer: net.Dialer{Timeout: config.DialTimeout},
+		packet: isPacketNetwork(config.Network),
+		now:    time.Now,
+	}
+}
+
+func (d *connDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.closed {
+		return ErrDumperClosed
+	}
+
+	if !d.packet {
+		return d.writeReconnecting(b)
+	}
+
+	for len(b) > 0 {
+		datagram := nextDatagram(b, d.config.MaxDatagramSize)
+
+		err := d.writeReconnecting(datagram)
+		if err != nil {
+			return err
+		}
+
+		b = b[len(datagram):]
+	}
+
+	return nil
+}
+
+// nextDatagram returns the longest prefix of b up to size bytes ending with a newline, or size bytes of b if there is
+// no newline in them.
+func nextDatagram(b []byte, size int) []byte {
+	if len(b) <= size {
+		return b
+	}
+
+	i := bytes.LastIndexByte(b[:size], '\n')
+	if i < 0 {
+		return b[:size]
+	}
+	return b[:i+1]
+}
+
+func isPacketNetwork(network string) bool {
+	return strings.HasPrefix(network, "udp") || strings.HasPrefix(network, "ip") || network == "unixgram"
+}
+
+func (d *connDumper) writeReconnecting(b []byte) error {
+	reconnected := d.conn == nil
+
+	err := d.write(b)
+	if err == nil || reconnected {
+		return err
+	}
+
+	return d.write(b)
+}
+
+func (d *connDumper) write(b []byte) error {
+	if d.conn == nil {
+		err := d.dial()
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.config.WriteTimeout > 0 {
+		err := d.conn.SetWriteDeadline(d.now().Add(d.config.WriteTimeout))
+		if err != nil {
+			d.disconnect()
+			return err
+		}
+	}
+
+	_, err := d.conn.Write(b)
+	if err != nil {
+		d.disconnect()
+	}
+
+	return err
+}
+
+func (d *connDumper) dial() error {
+	now := d.now()
+	if now.Before(d.nextDial) {
+		return ErrNotConnected
+	}
+
+	conn, err := d.dialer.Dial(d.config.Network, d.config.Address)
+	if err != nil {
+		if d.reconnectDelay == 0 {
+			d.reconnectDelay = d.config.MinReconnectDelay
+		} else {
+			d.reconnectDelay *= 2
+			if d.reconnectDelay > d.config.MaxReconnectDelay {
+				d.reconnectDelay = d.config.MaxReconnectDelay
+			}
+		}
+		d.nextDial = now.Add(d.reconnectDelay)
+		return err
+	}
+
+	d.conn = conn
+	d.reconnectDelay = 0
+	return nil
+}
+
+func (d *connDumper) disconnect() {
+	_ = d.conn.Close()
+	d.conn = nil
+}
+
+func (d *connDumper) Close() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.closed = true
+
+	if d.conn == nil {
+		return nil
+	}
+
+	err := d.conn.Close()
+	d.conn = nil
+	return err
+}
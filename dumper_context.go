@@ -0,0 +1,36 @@
+package alslgr
+
+import (
+	"context"
+)
+
+type (
+	contextDumperAdapter struct {
+		dumper Dumper
+	}
+)
+
+// NewContextDumperAdapter makes a plain Dumper usable where a ContextDumper is expected. Since a plain Dumper
+// can not be interrupted, the context is only checked before the dump is started.
+func NewContextDumperAdapter(dumper Dumper) ContextDumper {
+	return &contextDumperAdapter{
+		dumper: dumper,
+	}
+}
+
+func (a *contextDumperAdapter) Dump(ctx context.Context, b []byte) error {
+	err := ctx.Err()
+	if err != nil {
+		return err
+	}
+
+	return a.dumper.Dump(b)
+}
+
+func unwrapDumper(dumper ContextDumper) any {
+	a, ok := dumper.(*contextDumperAdapter)
+	if ok {
+		return a.dumper
+	}
+	return dumper
+}
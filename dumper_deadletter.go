@@ -0,0 +1,43 @@
+package alslgr
+
+import (
+	"errors"
+)
+
+type (
+	deadLetterDumper struct {
+		dumper     Dumper
+		deadLetter Dumper
+	}
+)
+
+// NewDeadLetterDumper creates a Dumper that redirects payloads failed to be dumped by dumper into deadLetter. A dump
+// is considered successful if either of them succeeded. Use DumperFunc to receive failed payloads in a callback.
+func NewDeadLetterDumper(dumper, deadLetter Dumper) Dumper {
+	return &deadLetterDumper{
+		dumper:     dumper,
+		deadLetter: deadLetter,
+	}
+}
+
+func (d *deadLetterDumper) Dump(b []byte) error {
+	err := d.dumper.Dump(b)
+	if err == nil {
+		return nil
+	}
+
+	deadLetterErr := d.deadLetter.Dump(b)
+	if deadLetterErr == nil {
+		return nil
+	}
+
+	return errors.Join(err, deadLetterErr)
+}
+
+func (d *deadLetterDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper, d.deadLetter})
+}
+
+func (d *deadLetterDumper) Close() error {
+	return closeDumpers([]Dumper{d.dumper, d.deadLetter})
+}
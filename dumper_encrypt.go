@@ -0,0 +1,148 @@
+package alslgr
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+	"sync"
+)
+
+type (
+	// StaticKey is a KeyProvider always returning the same AES key.
+	StaticKey []byte
+
+	encryptingDumper struct {
+		mx sync.Mutex
+
+		dumper Dumper
+		keys   KeyProvider
+		buf    []byte
+	}
+
+	decryptingReader struct {
+		r            *bufio.Reader
+		keys         KeyProvider
+		maxFrameSize int
+		header       [sealedHeaderSize]byte
+		sealed       []byte
+		opened       []byte
+	}
+)
+
+const (
+	// sealedHeaderSize is the size of the big-endian length preceding every sealed payload.
+	sealedHeaderSize = 4
+)
+
+func (k StaticKey) Key() ([]byte, error) {
+	return k, nil
+}
+
+// NewEncryptingDumper creates a Dumper that seals every payload with AES-GCM using the key of keys and a random nonce
+// before passing it to dumper. A sealed payload is prefixed with its length and the nonce, so sealed payloads written
+// one after another can be read back with NewDecryptingReader. The buffer of the sealed payload is reused, so dumper
+// must not retain it.
+func NewEncryptingDumper(dumper Dumper, keys KeyProvider) Dumper {
+	return &encryptingDumper{
+		dumper: dumper,
+		keys:   keys,
+	}
+}
+
+func (d *encryptingDumper) Dump(b []byte) error {
+	aead, err := newAEAD(d.keys)
+	if err != nil {
+		return err
+	}
+
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	size := aead.NonceSize() + len(b) + aead.Overhead()
+	if cap(d.buf) < sealedHeaderSize+size {
+		d.buf = make([]byte, sealedHeaderSize+size)
+	}
+	d.buf = d.buf[:sealedHeaderSize+aead.NonceSize()]
+
+	binary.BigEndian.PutUint32(d.buf, uint32(size))
+
+	nonce := d.buf[sealedHeaderSize:]
+	_, err = rand.Read(nonce)
+	if err != nil {
+		return err
+	}
+
+	d.buf = aead.Seal(d.buf, nonce, b, nil)
+
+	return d.dumper.Dump(d.buf)
+}
+
+func (d *encryptingDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *encryptingDumper) Close() error {
+	return closeDumpers([]Dumper{d.dumper})
+}
+
+// NewDecryptingReader creates a FrameReader returning payloads sealed by an EncryptingDumper using the key of keys.
+// WithMaxFrameSize limits the size of a sealed payload.
+func NewDecryptingReader(r io.Reader, keys KeyProvider, opts ...FrameReaderOption) FrameReader {
+	return &decryptingReader{
+		r:            bufio.NewReader(r),
+		keys:         keys,
+		maxFrameSize: newFrameReaderOptions(opts).maxFrameSize,
+	}
+}
+
+// Next returns the next payload, which is valid until the following call. It returns io.EOF once there are no more
+// payloads, io.ErrUnexpectedEOF if the last one is truncated and ErrCorruptFrame if it can not be opened or its length
+// exceeds the maximum frame size.
+func (r *decryptingReader) Next() ([]byte, error) {
+	aead, err := newAEAD(r.keys)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.ReadFull(r.r, r.header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(r.header[:])
+	if int(size) < aead.NonceSize()+aead.Overhead() || uint64(size) > uint64(r.maxFrameSize) {
+		return nil, ErrCorruptFrame
+	}
+
+	r.sealed, err = readFrame(r.r, r.sealed, int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, ciphertext := r.sealed[:aead.NonceSize()], r.sealed[aead.NonceSize():]
+
+	r.opened, err = aead.Open(r.opened[:0], nonce, ciphertext, nil)
+	if err != nil {
+		return nil, errors.Join(ErrCorruptFrame, err)
+	}
+
+	return r.opened, nil
+}
+
+func newAEAD(keys KeyProvider) (cipher.AEAD, error) {
+	key, err := keys.Key()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
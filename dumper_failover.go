@@ -0,0 +1,68 @@
+package alslgr
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+type (
+	failoverDumper struct {
+		mx sync.Mutex
+
+		dumpers       []Dumper
+		probeInterval time.Duration
+
+		current   int
+		lastProbe time.Time
+
+		now func() time.Time
+	}
+)
+
+// NewFailoverDumper creates a Dumper that dumps into the first of the given dumpers that succeeds, remembering it for
+// the next dumps. While a fallback dumper is in use, dumpers of higher priority are probed again every probeInterval.
+func NewFailoverDumper(probeInterval time.Duration, primary Dumper, fallbacks ...Dumper) Dumper {
+	return &failoverDumper{
+		dumpers:       append([]Dumper{primary}, fallbacks...),
+		probeInterval: probeInterval,
+		now:           time.Now,
+	}
+}
+
+func (d *failoverDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	now := d.now()
+
+	start := d.current
+	if start > 0 && now.Sub(d.lastProbe) >= d.probeInterval {
+		start = 0
+		d.lastProbe = now
+	}
+
+	var errs []error
+	for i := start; i < len(d.dumpers); i++ {
+		err := d.dumpers[i].Dump(b)
+		if err == nil {
+			if i > d.current {
+				d.lastProbe = now
+			}
+			d.current = i
+			return nil
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *failoverDumper) Reopen() error {
+	return reopenDumpers(d.dumpers)
+}
+
+func (d *failoverDumper) Close() error {
+	return closeDumpers(d.dumpers)
+}
@@ -0,0 +1,121 @@
+package alslgr
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type (
+	// FaultyDumperConfig defines faults injected by a FaultyDumper. Probabilities are in range [0, 1].
+	FaultyDumperConfig struct {
+		ErrorProbability        float64
+		PartialWriteProbability float64
+		Err                     error
+		Latency                 func(r *rand.Rand) time.Duration
+		Seed                    int64
+	}
+
+	faultyDumper struct {
+		dumper Dumper
+		config FaultyDumperConfig
+
+		mx   sync.Mutex
+		rand *rand.Rand
+
+		sleep func(time.Duration)
+	}
+)
+
+// NewFaultyDumper creates a Dumper injecting faults into dumps to the given one, for resilience tests of Logger and
+// Dumper configurations. Every dump is delayed by Latency, if set, then fails with Err, ErrInjected by default, with
+// ErrorProbability without reaching the Dumper. Otherwise, with PartialWriteProbability, only a random prefix of the
+// data is dumped before failing with Err, as a connection broken in the middle of a write does. A non-zero Seed makes
+// faults reproducible.
+func NewFaultyDumper(dumper Dumper, config FaultyDumperConfig) Dumper {
+	if config.Err == nil {
+		config.Err = ErrInjected
+	}
+
+	seed := config.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	return &faultyDumper{
+		dumper: dumper,
+		config: config,
+		rand:   rand.New(rand.NewSource(seed)), // #nosec G404
+		sleep:  time.Sleep,
+	}
+}
+
+// UniformLatency returns a latency distribution for FaultyDumperConfig uniform in range [min, max).
+func UniformLatency(min, max time.Duration) func(r *rand.Rand) time.Duration {
+	return func(r *rand.Rand) time.Duration {
+		if max <= min {
+			return min
+		}
+		return min + time.Duration(r.Int63n(int64(max-min)))
+	}
+}
+
+// ExponentialLatency returns a latency distribution for FaultyDumperConfig exponential with the given mean, which
+// produces occasional long stalls.
+func ExponentialLatency(mean time.Duration) func(r *rand.Rand) time.Duration {
+	return func(r *rand.Rand) time.Duration {
+		return time.Duration(r.ExpFloat64() * float64(mean))
+	}
+}
+
+func (d *faultyDumper) Dump(b []byte) error {
+	latency, fail, partial := d.roll(len(b))
+
+	if latency > 0 {
+		d.sleep(latency)
+	}
+
+	if fail {
+		return d.config.Err
+	}
+
+	if partial < 0 {
+		return d.dumper.Dump(b)
+	}
+
+	if partial > 0 {
+		err := d.dumper.Dump(b[:partial])
+		if err != nil {
+			return err
+		}
+	}
+	return d.config.Err
+}
+
+// roll decides faults of a dump of n bytes. partial is the number of bytes to dump before failing, or -1 if the dump
+// is not to be partial.
+func (d *faultyDumper) roll(n int) (latency time.Duration, fail bool, partial int) {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.config.Latency != nil {
+		latency = d.config.Latency(d.rand)
+	}
+
+	fail = d.rand.Float64() < d.config.ErrorProbability
+
+	partial = -1
+	if !fail && n > 0 && d.rand.Float64() < d.config.PartialWriteProbability {
+		partial = d.rand.Intn(n)
+	}
+
+	return latency, fail, partial
+}
+
+func (d *faultyDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *faultyDumper) Close() error {
+	return closeDumpers([]Dumper{d.dumper})
+}
@@ -1,13 +1,17 @@
 package alslgr
 
 import (
+	"errors"
 	"os"
 )
 
 type (
+	FileDumperOption func(d *fileDumper)
+
 	fileDumper struct {
 		dumpFilenameFunc func() string
 		filePerms        os.FileMode
+		lock             bool
 	}
 )
 
@@ -15,11 +19,26 @@ const (
 	FileDumperDefaultPerms = os.FileMode(0640) //rw-r-----
 )
 
-func NewFileDumper(dumpFilenameFunc func() string, perms os.FileMode) Dumper {
-	return &fileDumper{
+// WithFileLock makes the file Dumper hold an exclusive advisory lock (flock) on the file while a dump is written, and
+// verify the file is opened in append mode, so processes sharing the file never interleave parts of their dumps. Dumps
+// fail with errors.ErrUnsupported on platforms without flock.
+func WithFileLock() FileDumperOption {
+	return func(d *fileDumper) {
+		d.lock = true
+	}
+}
+
+func NewFileDumper(dumpFilenameFunc func() string, perms os.FileMode, opts ...FileDumperOption) Dumper {
+	d := &fileDumper{
 		dumpFilenameFunc: dumpFilenameFunc,
 		filePerms:        perms,
 	}
+
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
 }
 
 func (d *fileDumper) Dump(b []byte) error {
@@ -28,6 +47,13 @@ func (d *fileDumper) Dump(b []byte) error {
 		return err
 	}
 
+	if d.lock {
+		err = lockFile(f)
+		if err != nil {
+			return errors.Join(err, f.Close())
+		}
+	}
+
 	_, err = f.Write(b)
 	_ = f.Close()
 	return err
@@ -0,0 +1,9 @@
+package alslgr
+
+type (
+	DumperFunc func(b []byte) error
+)
+
+func (f DumperFunc) Dump(b []byte) error {
+	return f(b)
+}
@@ -0,0 +1,211 @@
+package alslgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type (
+	GELFDumperConfig struct {
+		Conn ConnDumperConfig
+
+		Host      string
+		Level     *SyslogSeverity
+		Fields    map[string]string
+		Compress  bool
+		ChunkSize int
+	}
+
+	gelfDumper struct {
+		mx sync.Mutex
+
+		conn   Dumper
+		config GELFDumperConfig
+		stream bool
+		codec  Codec
+
+		prefix     []byte
+		message    []byte
+		compressed []byte
+		chunk      []byte
+		messageID  uint64
+
+		now func() time.Time
+	}
+)
+
+const (
+	GELFDefaultChunkSize = 1420
+
+	gelfChunkHeaderSize = 12
+	gelfMaxChunks       = 128
+)
+
+var (
+	gelfChunkMagic = []byte{0x1e, 0x0f}
+)
+
+// NewGELFDumper creates a Dumper sending every line of a dump to Graylog as a separate GELF 1.1 message with
+// short_message set to the line, host to Host, the hostname by default, and level to Level, which is SyslogSeverityInfo
+// if nil. Fields are added to every message as additional fields, their names must not start with an underscore.
+// Over stream networks messages are terminated by a null byte. Over packet networks they are gzipped if Compress is
+// set and split into chunks of ChunkSize bytes, GELFDefaultChunkSize by default, if larger. The short_message of a
+// message exceeding 128 chunks is truncated, only a message exceeding them without it fails the dump with
+// ErrMessageTooLarge.
+func NewGELFDumper(config GELFDumperConfig) Dumper {
+	if config.Host == "" {
+		config.Host, _ = os.Hostname()
+	}
+	level := SyslogSeverityInfo
+	if config.Level != nil {
+		level = *config.Level
+	}
+	if config.ChunkSize <= gelfChunkHeaderSize {
+		config.ChunkSize = GELFDefaultChunkSize
+	}
+
+	d := &gelfDumper{
+		conn:      NewConnDumper(config.Conn),
+		config:    config,
+		stream:    !isPacketNetwork(config.Conn.Network),
+		messageID: rand.Uint64(), // #nosec G404
+		now:       time.Now,
+	}
+
+	if config.Compress {
+		d.codec = newGzipCodec(gzip.DefaultCompression)
+	}
+
+	d.prefix = append(d.prefix, `{"version":"1.1","host":`...)
+	d.prefix = appendJSONString(d.prefix, config.Host)
+	d.prefix = append(d.prefix, `,"level":`...)
+	d.prefix = strconv.AppendInt(d.prefix, int64(level), 10)
+	for name, value := range config.Fields {
+		d.prefix = append(d.prefix, ',')
+		d.prefix = appendJSONString(d.prefix, "_"+name)
+		d.prefix = append(d.prefix, ':')
+		d.prefix = appendJSONString(d.prefix, value)
+	}
+
+	return d
+}
+
+func (d *gelfDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	timestamp := float64(d.now().UnixMicro()) / 1e6
+
+	var stream []byte
+	for len(b) > 0 {
+		line := b
+		i := bytes.IndexByte(b, '\n')
+		if i >= 0 {
+			line, b = b[:i], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		if d.stream {
+			stream = append(stream, d.encode(timestamp, line)...)
+			stream = append(stream, 0)
+			continue
+		}
+
+		message, err := d.packet(timestamp, line)
+		if err == nil {
+			err = d.send(message)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(stream) == 0 {
+		return nil
+	}
+
+	return d.conn.Dump(stream)
+}
+
+func (d *gelfDumper) encode(timestamp float64, line []byte) []byte {
+	d.message = append(d.message[:0], d.prefix...)
+	d.message = append(d.message, `,"timestamp":`...)
+	d.message = strconv.AppendFloat(d.message, timestamp, 'f', 6, 64)
+	d.message = append(d.message, `,"short_message":`...)
+	d.message = appendJSONString(d.message, string(line))
+	return append(d.message, '}')
+}
+
+// packet returns the message of line to be sent over a packet network, compressed if configured. If the message does
+// not fit into gelfMaxChunks chunks, line is truncated until it does.
+func (d *gelfDumper) packet(timestamp float64, line []byte) ([]byte, error) {
+	maxSize := gelfMaxChunks * (d.config.ChunkSize - gelfChunkHeaderSize)
+
+	for {
+		message := d.encode(timestamp, line)
+		if d.codec != nil {
+			var err error
+			d.compressed, err = d.codec.Encode(d.compressed[:0], message)
+			if err != nil {
+				return nil, err
+			}
+			message = d.compressed
+		}
+
+		excess := len(message) - maxSize
+		if excess <= 0 || len(line) == 0 {
+			return message, nil
+		}
+
+		line = truncateUTF8(line, len(line)-excess)
+	}
+}
+
+// send sends a message over a packet network, chunked if it is larger than a chunk.
+func (d *gelfDumper) send(message []byte) error {
+	if len(message) <= d.config.ChunkSize {
+		return d.conn.Dump(message)
+	}
+
+	size := d.config.ChunkSize - gelfChunkHeaderSize
+	count := (len(message) + size - 1) / size
+	if count > gelfMaxChunks {
+		return ErrMessageTooLarge
+	}
+
+	d.messageID++
+	for i := 0; i < count; i++ {
+		d.chunk = append(d.chunk[:0], gelfChunkMagic...)
+		d.chunk = binary.BigEndian.AppendUint64(d.chunk, d.messageID)
+		d.chunk = append(d.chunk, byte(i), byte(count))
+		d.chunk = append(d.chunk, message[i*size:min((i+1)*size, len(message))]...)
+
+		err := d.conn.Dump(d.chunk)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func appendJSONString(dst []byte, s string) []byte {
+	b, _ := json.Marshal(s)
+	return append(dst, b...)
+}
+
+func (d *gelfDumper) Close() error {
+	return closeDumpers([]Dumper{d.conn})
+}
@@ -0,0 +1,111 @@
+package alslgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type (
+	HTTPDumperConfig struct {
+		URL         string
+		Method      string
+		Header      http.Header
+		ContentType string
+		Gzip        bool
+		Codec       Codec
+		Timeout     time.Duration
+		Client      *http.Client
+	}
+
+	HTTPStatusError struct {
+		StatusCode int
+		Status     string
+	}
+
+	httpDumper struct {
+		config HTTPDumperConfig
+	}
+)
+
+const (
+	HTTPDumperDefaultContentType = "application/octet-stream"
+)
+
+func (e *HTTPStatusError) Error() string {
+	return "unexpected http status " + strconv.Itoa(e.StatusCode) + ": " + e.Status
+}
+
+// NewHTTPDumper creates a Dumper sending each dump as a body of a request to URL, POST by default. If Codec is set,
+// the body is encoded with it and Content-Encoding is set to its ContentEncoding, Gzip is a shorthand for a gzip Codec
+// of the default level. A response with a status other than 2xx is returned as *HTTPStatusError.
+func NewHTTPDumper(config HTTPDumperConfig) Dumper {
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+	if config.ContentType == "" {
+		config.ContentType = HTTPDumperDefaultContentType
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Gzip && config.Codec == nil {
+		config.Codec = newGzipCodec(gzip.DefaultCompression)
+	}
+
+	return &httpDumper{
+		config: config,
+	}
+}
+
+func (d *httpDumper) Dump(b []byte) error {
+	ctx := context.Background()
+	if d.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+		defer cancel()
+	}
+
+	body := b
+	if d.config.Codec != nil {
+		var err error
+		body, err = d.config.Codec.Encode(nil, b)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, d.config.Method, d.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	for key, values := range d.config.Header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", d.config.ContentType)
+	if d.config.Codec != nil {
+		req.Header.Set("Content-Encoding", d.config.Codec.ContentEncoding())
+	}
+
+	resp, err := d.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return &HTTPStatusError{
+			StatusCode: resp.StatusCode,
+			Status:     resp.Status,
+		}
+	}
+
+	return nil
+}
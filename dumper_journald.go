@@ -0,0 +1,144 @@
+package alslgr
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strconv"
+	"sync"
+)
+
+type (
+	JournaldDumperConfig struct {
+		Socket string
+
+		Identifier  string
+		Priority    *SyslogSeverity
+		ParsePrefix bool
+		Fields      map[string]string
+
+		MaxEntrySize int
+	}
+
+	journaldDumper struct {
+		mx sync.Mutex
+
+		conn     Dumper
+		config   JournaldDumperConfig
+		priority SyslogSeverity
+
+		fields  []byte
+		message []byte
+	}
+)
+
+const (
+	JournaldDefaultSocket       = "/run/systemd/journal/socket"
+	JournaldDefaultMaxEntrySize = 64 << 10
+)
+
+// NewJournaldDumper creates a Dumper sending every line of a dump to systemd-journald as a separate entry over its
+// native protocol. Entries have SYSLOG_IDENTIFIER set to Identifier, if any, and PRIORITY set to Priority, which is
+// SyslogSeverityInfo if nil. If ParsePrefix is set, a line starting with a priority prefix as of sd-daemon, e.g.
+// "<3>", gets that priority and loses the prefix. Fields are added to every entry, their names must be valid journal
+// field names. An entry is sent in a single datagram, so the message of an entry longer than MaxEntrySize bytes,
+// JournaldDefaultMaxEntrySize by default, is truncated.
+func NewJournaldDumper(config JournaldDumperConfig) Dumper {
+	if config.Socket == "" {
+		config.Socket = JournaldDefaultSocket
+	}
+	if config.MaxEntrySize <= 0 {
+		config.MaxEntrySize = JournaldDefaultMaxEntrySize
+	}
+
+	d := &journaldDumper{
+		conn: NewConnDumper(ConnDumperConfig{
+			Network:         "unixgram",
+			Address:         config.Socket,
+			MaxDatagramSize: config.MaxEntrySize,
+		}),
+		config:   config,
+		priority: SyslogSeverityInfo,
+	}
+
+	if config.Priority != nil {
+		d.priority = *config.Priority
+	}
+
+	if config.Identifier != "" {
+		d.fields = appendJournaldField(d.fields, "SYSLOG_IDENTIFIER", []byte(config.Identifier))
+	}
+	for name, value := range config.Fields {
+		d.fields = appendJournaldField(d.fields, name, []byte(value))
+	}
+
+	return d
+}
+
+func (d *journaldDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	for len(b) > 0 {
+		line := b
+		i := bytes.IndexByte(b, '\n')
+		if i >= 0 {
+			line, b = b[:i], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		priority := d.priority
+		if d.config.ParsePrefix {
+			priority, line = parseJournaldPrefix(line, priority)
+		}
+
+		d.message = append(d.message[:0], d.fields...)
+		d.message = append(d.message, "PRIORITY="...)
+		d.message = strconv.AppendInt(d.message, int64(priority), 10)
+		d.message = append(d.message, '\n')
+
+		// Lines contain no newlines, so the message is encoded as MESSAGE=line followed by a newline.
+		line = truncateUTF8(line, d.config.MaxEntrySize-len(d.message)-len("MESSAGE=\n"))
+		d.message = appendJournaldField(d.message, "MESSAGE", line)
+
+		err := d.conn.Dump(d.message)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseJournaldPrefix strips a priority prefix like "<3>" from line and returns the priority, or def if there is none.
+func parseJournaldPrefix(line []byte, def SyslogSeverity) (SyslogSeverity, []byte) {
+	if len(line) < 3 || line[0] != '<' || line[2] != '>' || line[1] < '0' || line[1] > '7' {
+		return def, line
+	}
+	return SyslogSeverity(line[1] - '0'), line[3:]
+}
+
+// appendJournaldField appends a field in the format of the native protocol, values containing newlines are prefixed
+// with their length instead of being terminated by a newline.
+func appendJournaldField(dst []byte, name string, value []byte) []byte {
+	dst = append(dst, name...)
+
+	if bytes.IndexByte(value, '\n') < 0 {
+		dst = append(dst, '=')
+		dst = append(dst, value...)
+		return append(dst, '\n')
+	}
+
+	dst = append(dst, '\n')
+	dst = binary.LittleEndian.AppendUint64(dst, uint64(len(value)))
+	dst = append(dst, value...)
+	return append(dst, '\n')
+}
+
+func (d *journaldDumper) Close() error {
+	return closeDumpers([]Dumper{d.conn})
+}
@@ -0,0 +1,73 @@
+package alslgr
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+type (
+	multiDumper struct {
+		dumpers []Dumper
+	}
+)
+
+func NewMultiDumper(dumpers ...Dumper) Dumper {
+	return &multiDumper{
+		dumpers: dumpers,
+	}
+}
+
+func (d *multiDumper) Dump(b []byte) error {
+	if len(d.dumpers) == 1 {
+		return d.dumpers[0].Dump(b)
+	}
+
+	errs := make([]error, len(d.dumpers))
+
+	var wg sync.WaitGroup
+	for i, dumper := range d.dumpers {
+		wg.Add(1)
+		go func(i int, dumper Dumper) {
+			defer wg.Done()
+			errs[i] = dumper.Dump(b)
+		}(i, dumper)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (d *multiDumper) Close() error {
+	return closeDumpers(d.dumpers)
+}
+
+func (d *multiDumper) Reopen() error {
+	return reopenDumpers(d.dumpers)
+}
+
+func reopenDumpers(dumpers []Dumper) error {
+	var errs []error
+
+	for _, dumper := range dumpers {
+		reopenable, ok := dumper.(ReopenableDumper)
+		if ok {
+			errs = append(errs, reopenable.Reopen())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func closeDumpers(dumpers []Dumper) error {
+	var errs []error
+
+	for _, dumper := range dumpers {
+		closer, ok := dumper.(io.Closer)
+		if ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
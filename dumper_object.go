@@ -0,0 +1,80 @@
+package alslgr
+
+import (
+	"compress/gzip"
+	"context"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// ObjectStore stores an object under the given key, an S3 PutObject call is a typical implementation.
+	ObjectStore interface {
+		PutObject(ctx context.Context, key string, body []byte, contentEncoding string) error
+	}
+
+	ObjectStoreDumperConfig struct {
+		KeyTemplate string
+		Gzip        bool
+		Codec       Codec
+		Timeout     time.Duration
+	}
+
+	objectStoreDumper struct {
+		store  ObjectStore
+		config ObjectStoreDumperConfig
+
+		seq atomic.Uint64
+		now func() time.Time
+	}
+)
+
+const (
+	ObjectStoreDumperDefaultKeyTemplate = "2006/01/02/150405.000000000-{seq}.log"
+
+	objectKeySeqPlaceholder = "{seq}"
+)
+
+// NewObjectStoreDumper creates a Dumper uploading each dump as a new object. The key of an object is KeyTemplate
+// formatted as a time layout with the time of the dump, where {seq} is replaced by the sequence number of the dump. If
+// Codec is set, the object is encoded with it and stored with its ContentEncoding, Gzip is a shorthand for a gzip Codec
+// of the default level.
+func NewObjectStoreDumper(store ObjectStore, config ObjectStoreDumperConfig) Dumper {
+	if config.KeyTemplate == "" {
+		config.KeyTemplate = ObjectStoreDumperDefaultKeyTemplate
+	}
+	if config.Gzip && config.Codec == nil {
+		config.Codec = newGzipCodec(gzip.DefaultCompression)
+	}
+
+	return &objectStoreDumper{
+		store:  store,
+		config: config,
+		now:    time.Now,
+	}
+}
+
+func (d *objectStoreDumper) Dump(b []byte) error {
+	ctx := context.Background()
+	if d.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, d.config.Timeout)
+		defer cancel()
+	}
+
+	seq := strconv.FormatUint(d.seq.Add(1), 10)
+	key := strings.ReplaceAll(d.now().Format(d.config.KeyTemplate), objectKeySeqPlaceholder, seq)
+
+	if d.config.Codec == nil {
+		return d.store.PutObject(ctx, key, b, "")
+	}
+
+	body, err := d.config.Codec.Encode(nil, b)
+	if err != nil {
+		return err
+	}
+
+	return d.store.PutObject(ctx, key, body, d.config.Codec.ContentEncoding())
+}
@@ -0,0 +1,82 @@
+package alslgr
+
+import (
+	"time"
+)
+
+type (
+	RetryDumperConfig struct {
+		MaxAttempts  int
+		InitialDelay time.Duration
+		MaxDelay     time.Duration
+		Multiplier   float64
+		Jitter       float64
+		Retryable    func(err error) bool
+	}
+
+	retryDumper struct {
+		dumper Dumper
+		config RetryDumperConfig
+
+		sleep func(time.Duration)
+	}
+)
+
+const (
+	RetryDumperDefaultMaxAttempts  = 3
+	RetryDumperDefaultInitialDelay = time.Millisecond * 100
+	RetryDumperDefaultMultiplier   = 2
+)
+
+// NewRetryDumper creates a Dumper that retries failed dumps up to MaxAttempts times in total. Delay between attempts
+// starts with InitialDelay and is multiplied by Multiplier after every attempt, limited by MaxDelay if it is positive.
+// Each delay is randomly deviated by up to Jitter fraction of it. Only errors accepted by Retryable are retried, nil
+// Retryable means all errors are.
+func NewRetryDumper(dumper Dumper, config RetryDumperConfig) Dumper {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = RetryDumperDefaultMaxAttempts
+	}
+	if config.InitialDelay <= 0 {
+		config.InitialDelay = RetryDumperDefaultInitialDelay
+	}
+	if config.Multiplier < 1 {
+		config.Multiplier = RetryDumperDefaultMultiplier
+	}
+
+	return &retryDumper{
+		dumper: dumper,
+		config: config,
+		sleep:  time.Sleep,
+	}
+}
+
+func (d *retryDumper) Dump(b []byte) error {
+	delay := d.config.InitialDelay
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = d.dumper.Dump(b)
+		if err == nil || attempt >= d.config.MaxAttempts {
+			return err
+		}
+
+		if d.config.Retryable != nil && !d.config.Retryable(err) {
+			return err
+		}
+
+		d.sleep(jitter(delay, d.config.Jitter))
+
+		delay = time.Duration(float64(delay) * d.config.Multiplier)
+		if d.config.MaxDelay > 0 && delay > d.config.MaxDelay {
+			delay = d.config.MaxDelay
+		}
+	}
+}
+
+func (d *retryDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *retryDumper) Close() error {
+	return closeDumpers([]Dumper{d.dumper})
+}
@@ -0,0 +1,213 @@
+package alslgr
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+)
+
+type (
+	RotatingFileDumperConfig struct {
+		Filename   string
+		Perms      os.FileMode
+		MaxSize    int64
+		MaxBackups int
+		Compress   bool
+	}
+
+	rotatingFileDumper struct {
+		mx sync.Mutex
+
+		config RotatingFileDumperConfig
+
+		file   *os.File
+		size   int64
+		closed bool
+	}
+)
+
+const (
+	compressedFileExt = ".gz"
+)
+
+func NewRotatingFileDumper(config RotatingFileDumperConfig) Dumper {
+	if config.Perms == 0 {
+		config.Perms = FileDumperDefaultPerms
+	}
+
+	return &rotatingFileDumper{
+		config: config,
+	}
+}
+
+func (d *rotatingFileDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.closed {
+		return ErrDumperClosed
+	}
+
+	if d.file == nil {
+		err := d.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.config.MaxSize > 0 && d.size > 0 && d.size+int64(len(b)) > d.config.MaxSize {
+		err := d.rotate()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := d.file.Write(b)
+	d.size += int64(n)
+	return err
+}
+
+// Reopen closes the file, so it is opened again by the next dump.
+func (d *rotatingFileDumper) Reopen() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.closeFile()
+}
+
+func (d *rotatingFileDumper) Close() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.closed = true
+	return d.closeFile()
+}
+
+func (d *rotatingFileDumper) closeFile() error {
+	if d.file == nil {
+		return nil
+	}
+
+	err := d.file.Close()
+	d.file = nil
+	return err
+}
+
+func (d *rotatingFileDumper) open() error {
+	f, err := os.OpenFile(d.config.Filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, d.config.Perms)
+	if err != nil {
+		return err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	d.file = f
+	d.size = info.Size()
+	return nil
+}
+
+func (d *rotatingFileDumper) rotate() error {
+	err := d.file.Close()
+	d.file = nil
+	if err != nil {
+		return err
+	}
+
+	if d.config.MaxBackups <= 0 {
+		err = removeIfExists(d.config.Filename)
+		if err != nil {
+			return err
+		}
+		return d.open()
+	}
+
+	err = removeIfExists(d.backupName(d.config.MaxBackups))
+	if err != nil {
+		return err
+	}
+
+	for i := d.config.MaxBackups - 1; i > 0; i-- {
+		err = renameIfExists(d.backupName(i), d.backupName(i+1))
+		if err != nil {
+			return err
+		}
+	}
+
+	backup := d.config.Filename + ".1"
+	err = os.Rename(d.config.Filename, backup)
+	if err != nil {
+		return err
+	}
+
+	if d.config.Compress {
+		err = compressFile(backup, d.config.Perms)
+		if err != nil {
+			return err
+		}
+	}
+
+	return d.open()
+}
+
+func (d *rotatingFileDumper) backupName(i int) string {
+	name := d.config.Filename + "." + strconv.Itoa(i)
+	if d.config.Compress {
+		name += compressedFileExt
+	}
+	return name
+}
+
+func removeIfExists(name string) error {
+	err := os.Remove(name)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func renameIfExists(from, to string) error {
+	err := os.Rename(from, to)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func compressFile(name string, perms os.FileMode) error {
+	src, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = src.Close()
+	}()
+
+	dst, err := os.OpenFile(name+compressedFileExt, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms)
+	if err != nil {
+		return err
+	}
+
+	zw := gzip.NewWriter(dst)
+
+	_, err = io.Copy(zw, src)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = dst.Close()
+	} else {
+		_ = dst.Close()
+	}
+	if err != nil {
+		_ = os.Remove(name + compressedFileExt)
+		return err
+	}
+
+	return os.Remove(name)
+}
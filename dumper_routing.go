@@ -0,0 +1,121 @@
+package alslgr
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+type (
+	// Route sends records accepted by Match, or all records if it is nil, to Dumper. Records accepted by a route are
+	// not offered to the following routes unless Continue is set.
+	Route struct {
+		Name     string
+		Match    func(record []byte) bool
+		Dumper   Dumper
+		Continue bool
+	}
+
+	routingDumper struct {
+		mx sync.Mutex
+
+		routes  []Route
+		records [][][]byte
+		payload []byte
+	}
+)
+
+// NewRoutingDumper creates a BatchDumper sending every record to dumpers of the routes accepting it, in one dump per
+// route. Records accepted by no route are discarded. Route dumpers implementing BatchDumper receive records as they
+// are.
+func NewRoutingDumper(routes ...Route) Dumper {
+	return &routingDumper{
+		routes:  routes,
+		records: make([][][]byte, len(routes)),
+	}
+}
+
+// PrefixMatch returns a Route.Match accepting records starting with prefix.
+func PrefixMatch(prefix string) func(record []byte) bool {
+	return func(record []byte) bool {
+		return bytes.HasPrefix(record, []byte(prefix))
+	}
+}
+
+// ContainsMatch returns a Route.Match accepting records containing substr.
+func ContainsMatch(substr string) func(record []byte) bool {
+	return func(record []byte) bool {
+		return bytes.Contains(record, []byte(substr))
+	}
+}
+
+func (d *routingDumper) Dump(b []byte) error {
+	return d.DumpMany([][]byte{b})
+}
+
+func (d *routingDumper) DumpMany(records [][]byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	for i := range d.records {
+		d.records[i] = d.records[i][:0]
+	}
+
+	for _, record := range records {
+		for i, route := range d.routes {
+			if route.Match != nil && !route.Match(record) {
+				continue
+			}
+
+			d.records[i] = append(d.records[i], record)
+			if !route.Continue {
+				break
+			}
+		}
+	}
+
+	var errs []error
+	for i, route := range d.routes {
+		if len(d.records[i]) == 0 {
+			continue
+		}
+
+		err := d.dumpRoute(route.Dumper, d.records[i])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("route %s: %w", route.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d *routingDumper) dumpRoute(dumper Dumper, records [][]byte) error {
+	batchDumper, ok := dumper.(BatchDumper)
+	if ok {
+		return batchDumper.DumpMany(records)
+	}
+
+	d.payload = d.payload[:0]
+	for _, record := range records {
+		d.payload = append(d.payload, record...)
+	}
+
+	return dumper.Dump(d.payload)
+}
+
+func (d *routingDumper) Reopen() error {
+	return reopenDumpers(d.dumpers())
+}
+
+func (d *routingDumper) Close() error {
+	return closeDumpers(d.dumpers())
+}
+
+func (d *routingDumper) dumpers() []Dumper {
+	dumpers := make([]Dumper, len(d.routes))
+	for i, route := range d.routes {
+		dumpers[i] = route.Dumper
+	}
+	return dumpers
+}
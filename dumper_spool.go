@@ -0,0 +1,234 @@
+package alslgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	SpoolingDumperConfig struct {
+		Dir           string
+		MaxSize       int64
+		DrainInterval time.Duration
+	}
+
+	spoolingDumper struct {
+		mx sync.Mutex
+
+		dumper Dumper
+		config SpoolingDumperConfig
+
+		loaded bool
+		files  []string
+		sizes  []int64
+		size   int64
+		gen    uint64
+
+		closed bool
+
+		cancel  context.CancelFunc
+		drained chan struct{}
+	}
+)
+
+const (
+	SpoolingDumperDefaultDrainInterval = time.Second
+
+	spoolFileExt = ".spool"
+)
+
+// NewSpoolingDumper creates a Dumper that appends payloads failed to be dumped by dumper to files of Dir, holding up to
+// MaxSize bytes if it is positive, and dumps them back in background every DrainInterval, oldest first, once dumper
+// recovers. While the spool is not empty, payloads go straight to it, so the order of payloads is preserved. A dump
+// fails only if dumper fails and the payload can not be spooled, e.g. with ErrSpoolFull or because Dir can not be
+// created, which is retried on every dump. Files left by a previous run are drained too, Close makes a final attempt to
+// drain the spool and keeps the rest on disk, dumps fail with ErrDumperClosed after it.
+func NewSpoolingDumper(dumper Dumper, config SpoolingDumperConfig) Dumper {
+	if config.DrainInterval <= 0 {
+		config.DrainInterval = SpoolingDumperDefaultDrainInterval
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	d := &spoolingDumper{
+		dumper:  dumper,
+		config:  config,
+		gen:     uint64(time.Now().UnixNano()),
+		cancel:  cancel,
+		drained: make(chan struct{}),
+	}
+
+	go d.drainWorker(ctx)
+
+	return d
+}
+
+func (d *spoolingDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.closed {
+		return ErrDumperClosed
+	}
+
+	// The spool is needed only once dumper fails, so it being unavailable does not fail a successful dump.
+	loadErr := d.load()
+
+	var err error
+	if loadErr != nil || len(d.files) == 0 {
+		err = d.dumper.Dump(b)
+		if err == nil {
+			return nil
+		}
+	}
+
+	if loadErr != nil {
+		return errors.Join(err, loadErr)
+	}
+
+	spoolErr := d.spool(b)
+	if spoolErr != nil {
+		return errors.Join(err, spoolErr)
+	}
+
+	return nil
+}
+
+// load creates the spool directory and picks up files left in it, once it succeeds.
+func (d *spoolingDumper) load() error {
+	if d.loaded {
+		return nil
+	}
+
+	err := os.MkdirAll(d.config.Dir, 0750)
+	if err != nil {
+		return err
+	}
+
+	names, err := filepath.Glob(filepath.Join(d.config.Dir, "*"+spoolFileExt))
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	sizes := make([]int64, len(names))
+	for i, name := range names {
+		info, err := os.Stat(name)
+		if err != nil {
+			return err
+		}
+		sizes[i] = info.Size()
+	}
+
+	for i := range names {
+		d.size += sizes[i]
+	}
+	d.files, d.sizes = names, sizes
+
+	d.loaded = true
+	return nil
+}
+
+// spool appends b to the spool as a new file.
+func (d *spoolingDumper) spool(b []byte) error {
+	if d.config.MaxSize > 0 && d.size+int64(len(b)) > d.config.MaxSize {
+		return ErrSpoolFull
+	}
+
+	d.gen++
+	name := filepath.Join(d.config.Dir, fmt.Sprintf("%020d%s", d.gen, spoolFileExt))
+
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, FileDumperDefaultPerms)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(b)
+	if err == nil {
+		err = f.Sync()
+	}
+	err = errors.Join(err, f.Close())
+	if err != nil {
+		return errors.Join(err, os.Remove(name))
+	}
+
+	d.files = append(d.files, name)
+	d.sizes = append(d.sizes, int64(len(b)))
+	d.size += int64(len(b))
+
+	return nil
+}
+
+func (d *spoolingDumper) drainWorker(ctx context.Context) {
+	defer close(d.drained)
+
+	ticker := time.NewTicker(d.config.DrainInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			_ = d.drain()
+		}
+	}
+}
+
+// drain dumps spooled files oldest first until one fails. Only drain removes files, so the oldest one can be dumped
+// without holding the lock, while new payloads are appended to the spool.
+func (d *spoolingDumper) drain() error {
+	for {
+		d.mx.Lock()
+		err := d.load()
+		if err != nil || len(d.files) == 0 {
+			d.mx.Unlock()
+			return err
+		}
+		name := d.files[0]
+		d.mx.Unlock()
+
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		err = d.dumper.Dump(b)
+		if err != nil {
+			return err
+		}
+
+		d.mx.Lock()
+		d.size -= d.sizes[0]
+		d.files, d.sizes = d.files[1:], d.sizes[1:]
+		d.mx.Unlock()
+
+		err = os.Remove(name)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (d *spoolingDumper) Reopen() error {
+	return reopenDumpers([]Dumper{d.dumper})
+}
+
+func (d *spoolingDumper) Close() error {
+	d.mx.Lock()
+	d.closed = true
+	d.mx.Unlock()
+
+	d.cancel()
+	<-d.drained
+
+	_ = d.drain()
+
+	return closeDumpers([]Dumper{d.dumper})
+}
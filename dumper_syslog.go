@@ -0,0 +1,168 @@
+package alslgr
+
+import (
+	"bytes"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	SyslogFacility int
+	SyslogSeverity int
+
+	SyslogDumperConfig struct {
+		Conn ConnDumperConfig
+
+		Facility SyslogFacility
+		Severity SyslogSeverity
+		Hostname string
+		AppName  string
+	}
+
+	syslogDumper struct {
+		mx sync.Mutex
+
+		conn   Dumper
+		config SyslogDumperConfig
+		stream bool
+		procID string
+
+		message []byte
+		scratch []byte
+
+		now func() time.Time
+	}
+)
+
+const (
+	SyslogFacilityKern SyslogFacility = iota
+	SyslogFacilityUser
+	SyslogFacilityMail
+	SyslogFacilityDaemon
+	SyslogFacilityAuth
+	SyslogFacilitySyslog
+	SyslogFacilityLpr
+	SyslogFacilityNews
+	SyslogFacilityUucp
+	SyslogFacilityCron
+	SyslogFacilityAuthPriv
+	SyslogFacilityFtp
+)
+
+const (
+	SyslogFacilityLocal0 SyslogFacility = iota + 16
+	SyslogFacilityLocal1
+	SyslogFacilityLocal2
+	SyslogFacilityLocal3
+	SyslogFacilityLocal4
+	SyslogFacilityLocal5
+	SyslogFacilityLocal6
+	SyslogFacilityLocal7
+)
+
+const (
+	SyslogSeverityEmergency SyslogSeverity = iota
+	SyslogSeverityAlert
+	SyslogSeverityCritical
+	SyslogSeverityError
+	SyslogSeverityWarning
+	SyslogSeverityNotice
+	SyslogSeverityInfo
+	SyslogSeverityDebug
+)
+
+const (
+	syslogNilValue  = "-"
+	syslogTimestamp = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// NewSyslogDumper creates a Dumper sending every line of a dump as a separate RFC 5424 message. Messages are sent
+// one per datagram over packet networks and with octet-counting framing (RFC 6587) over stream ones.
+func NewSyslogDumper(config SyslogDumperConfig) Dumper {
+	if config.Hostname == "" {
+		config.Hostname, _ = os.Hostname()
+	}
+
+	return &syslogDumper{
+		conn:   NewConnDumper(config.Conn),
+		config: config,
+		stream: !strings.HasPrefix(config.Conn.Network, "udp") && config.Conn.Network != "unixgram",
+		procID: strconv.Itoa(os.Getpid()),
+		now:    time.Now,
+	}
+}
+
+func (d *syslogDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	timestamp := d.now().Format(syslogTimestamp)
+
+	d.message = d.message[:0]
+	for len(b) > 0 {
+		line := b
+		i := bytes.IndexByte(b, '\n')
+		if i >= 0 {
+			line, b = b[:i], b[i+1:]
+		} else {
+			b = nil
+		}
+
+		if len(line) == 0 {
+			continue
+		}
+
+		d.scratch = d.appendMessage(d.scratch[:0], timestamp, line)
+
+		if d.stream {
+			d.message = strconv.AppendInt(d.message, int64(len(d.scratch)), 10)
+			d.message = append(d.message, ' ')
+			d.message = append(d.message, d.scratch...)
+			continue
+		}
+
+		err := d.conn.Dump(d.scratch)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(d.message) == 0 {
+		return nil
+	}
+
+	return d.conn.Dump(d.message)
+}
+
+func (d *syslogDumper) appendMessage(dst []byte, timestamp string, line []byte) []byte {
+	dst = append(dst, '<')
+	dst = strconv.AppendInt(dst, int64(d.config.Facility)*8+int64(d.config.Severity), 10)
+	dst = append(dst, ">1 "...)
+	dst = append(dst, timestamp...)
+	dst = append(dst, ' ')
+	dst = appendSyslogField(dst, d.config.Hostname)
+	dst = append(dst, ' ')
+	dst = appendSyslogField(dst, d.config.AppName)
+	dst = append(dst, ' ')
+	dst = append(dst, d.procID...)
+	dst = append(dst, ' ')
+	dst = append(dst, syslogNilValue...)
+	dst = append(dst, ' ')
+	dst = append(dst, syslogNilValue...)
+	dst = append(dst, ' ')
+	return append(dst, line...)
+}
+
+func appendSyslogField(dst []byte, value string) []byte {
+	if value == "" {
+		return append(dst, syslogNilValue...)
+	}
+	return append(dst, value...)
+}
+
+func (d *syslogDumper) Close() error {
+	return closeDumpers([]Dumper{d.conn})
+}
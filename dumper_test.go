@@ -0,0 +1,1232 @@
+package alslgr
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func readFile(t *testing.T, name string) string {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Errorf("FAILED TO READ FILE \"%s\": %v\n", name, err)
+	}
+	return string(b)
+}
+
+func readGzipFile(t *testing.T, name string) string {
+	f, err := os.Open(name)
+	if err != nil {
+		t.Errorf("FAILED TO OPEN FILE \"%s\": %v\n", name, err)
+		return ""
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		t.Errorf("FAILED TO READ GZIP FILE \"%s\": %v\n", name, err)
+		return ""
+	}
+
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Errorf("FAILED TO READ GZIP FILE \"%s\": %v\n", name, err)
+	}
+	return string(b)
+}
+
+func TestRotatingFileDumper(t *testing.T) {
+	for _, compress := range []bool{false, true} {
+		name := filepath.Join(t.TempDir(), "app.log")
+
+		d := NewRotatingFileDumper(RotatingFileDumperConfig{
+			Filename:   name,
+			MaxSize:    4,
+			MaxBackups: 2,
+			Compress:   compress,
+		})
+
+		for _, data := range []string{"AAA", "BBB", "CCC", "DDD"} {
+			err := d.Dump([]byte(data))
+			if err != nil {
+				t.Errorf("TEST \"ROTATING FILE DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+			}
+		}
+
+		err := d.(io.Closer).Close()
+		if err != nil {
+			t.Errorf("TEST \"ROTATING FILE DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		read := readFile
+		ext := ""
+		if compress {
+			read = readGzipFile
+			ext = compressedFileExt
+		}
+
+		given := readFile(t, name)
+		if given != "DDD" {
+			t.Errorf("TEST \"ROTATING FILE DUMPER\" FAILED: EXPECTED FILE \"%s\" DATA %s GOT %s\n", name, "DDD", given)
+		}
+
+		expected := map[string]string{
+			name + ".1" + ext: "CCC",
+			name + ".2" + ext: "BBB",
+		}
+		for file, data := range expected {
+			given := read(t, file)
+			if given != data {
+				t.Errorf("TEST \"ROTATING FILE DUMPER\" FAILED: EXPECTED FILE \"%s\" DATA %s GOT %s\n", file, data, given)
+			}
+		}
+
+		_, err = os.Stat(name + ".3" + ext)
+		if !os.IsNotExist(err) {
+			t.Errorf("TEST \"ROTATING FILE DUMPER\" FAILED: EXPECTED NO THIRD BACKUP GOT \"%v\"\n", err)
+		}
+	}
+}
+
+func TestTimedFileDumper(t *testing.T) {
+	dir := t.TempDir()
+
+	d := NewTimedFileDumper(TimedFileDumperConfig{
+		Pattern:   filepath.Join(dir, "app-2006-01-02.log"),
+		Retention: time.Hour * 36,
+	})
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	d.(*timedFileDumper).now = func() time.Time {
+		return now
+	}
+
+	for _, data := range []string{"A", "B", "C"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"TIMED FILE DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+		now = now.Add(time.Hour * 24)
+	}
+
+	err := d.(io.Closer).Close()
+	if err != nil {
+		t.Errorf("TEST \"TIMED FILE DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, err = os.Stat(filepath.Join(dir, "app-2023-01-01.log"))
+	if !os.IsNotExist(err) {
+		t.Errorf("TEST \"TIMED FILE DUMPER\" FAILED: EXPECTED EXPIRED FILE TO BE REMOVED GOT \"%v\"\n", err)
+	}
+
+	expected := map[string]string{
+		"app-2023-01-02.log": "B",
+		"app-2023-01-03.log": "C",
+	}
+	for file, data := range expected {
+		given := readFile(t, filepath.Join(dir, file))
+		if given != data {
+			t.Errorf("TEST \"TIMED FILE DUMPER\" FAILED: EXPECTED FILE \"%s\" DATA %s GOT %s\n", file, data, given)
+		}
+	}
+}
+
+func TestTimedFileDumperShortRetention(t *testing.T) {
+	dir := t.TempDir()
+
+	d := NewTimedFileDumper(TimedFileDumperConfig{
+		Pattern:   filepath.Join(dir, "app-2006-01-02.log"),
+		Retention: time.Hour,
+	})
+
+	d.(*timedFileDumper).now = func() time.Time {
+		return time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	}
+
+	for _, data := range []string{"A", "B"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"TIMED FILE DUMPER SHORT RETENTION\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := d.(io.Closer).Close()
+	if err != nil {
+		t.Errorf("TEST \"TIMED FILE DUMPER SHORT RETENTION\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	given := readFile(t, filepath.Join(dir, "app-2023-01-01.log"))
+	if given != "AB" {
+		t.Errorf("TEST \"TIMED FILE DUMPER SHORT RETENTION\" FAILED: EXPECTED DATA %s GOT %s\n", "AB", given)
+	}
+}
+
+func TestTimedFileDumperCleanupError(t *testing.T) {
+	dir := t.TempDir()
+
+	errUndeletable := errors.New("UNDELETABLE")
+
+	var cleanupErrs []error
+	d := NewTimedFileDumper(TimedFileDumperConfig{
+		Pattern:   filepath.Join(dir, "app-2006-01-02.log"),
+		Retention: time.Hour,
+		OnCleanupError: func(err error) {
+			cleanupErrs = append(cleanupErrs, err)
+		},
+	})
+
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.Local)
+	d.(*timedFileDumper).now = func() time.Time {
+		return now
+	}
+	d.(*timedFileDumper).remove = func(string) error {
+		return errUndeletable
+	}
+
+	for _, data := range []string{"A", "B"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"TIMED FILE DUMPER CLEANUP ERROR\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+		now = now.Add(time.Hour * 24)
+	}
+
+	_ = d.(io.Closer).Close()
+
+	given := readFile(t, filepath.Join(dir, "app-2023-01-02.log"))
+	if given != "B" || len(cleanupErrs) != 1 || !errors.Is(cleanupErrs[0], errUndeletable) {
+		t.Errorf("TEST \"TIMED FILE DUMPER CLEANUP ERROR\" FAILED: EXPECTED DATA %s AND ERRORS [%v] GOT %s AND %v\n",
+			"B", errUndeletable, given, cleanupErrs)
+	}
+}
+
+func TestFileDumperLock(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "shared.log")
+
+	newDumper := func() Dumper {
+		return NewFileDumper(func() string {
+			return name
+		}, FileDumperDefaultPerms, WithFileLock())
+	}
+
+	err := newDumper().Dump(nil)
+	if errors.Is(err, errors.ErrUnsupported) {
+		t.Skip("FILE LOCKING IS NOT SUPPORTED\n")
+	}
+
+	const writers, size = 4, 1 << 16
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(c byte) {
+			defer wg.Done()
+
+			err := newDumper().Dump(bytes.Repeat([]byte{c}, size))
+			if err != nil {
+				t.Errorf("TEST \"FILE DUMPER LOCK\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+			}
+		}('A' + byte(i))
+	}
+	wg.Wait()
+
+	data := readFile(t, name)
+	if len(data) != writers*size {
+		t.Fatalf("TEST \"FILE DUMPER LOCK\" FAILED: EXPECTED %d BYTES GOT %d\n", writers*size, len(data))
+	}
+
+	for i := 0; i < writers; i++ {
+		chunk := data[i*size : (i+1)*size]
+		if strings.Count(chunk, chunk[:1]) != size {
+			t.Errorf("TEST \"FILE DUMPER LOCK\" FAILED: EXPECTED DUMP %d NOT TO BE INTERLEAVED\n", i)
+		}
+	}
+}
+
+func TestMultiDumper(t *testing.T) {
+	d1, d2, d3 := &TestDumper{}, &TestDumper{}, &TestDumper{}
+	d := NewMultiDumper(d1, d2, d3)
+
+	err := d.Dump([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"MULTI DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = d.Dump([]byte(ForcedErrorMessage))
+	if !errors.Is(err, forcedError) {
+		t.Errorf("TEST \"MULTI DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", forcedError, err)
+	}
+
+	for _, td := range []*TestDumper{d1, d2, d3} {
+		givenResult := string((*bytes.Buffer)(td).Bytes())
+		if givenResult != "A" {
+			t.Errorf("TEST \"MULTI DUMPER\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+		}
+	}
+}
+
+type (
+	SwitchableTestDumper struct {
+		TestDumper
+		fail bool
+	}
+)
+
+func (d *SwitchableTestDumper) Dump(b []byte) error {
+	if d.fail {
+		return forcedError
+	}
+	return d.TestDumper.Dump(b)
+}
+
+func TestFailoverDumper(t *testing.T) {
+	primary := &SwitchableTestDumper{fail: true}
+	fallback := &TestDumper{}
+
+	d := NewFailoverDumper(time.Minute, primary, fallback)
+
+	now := time.Now()
+	d.(*failoverDumper).now = func() time.Time {
+		return now
+	}
+
+	steps := []struct {
+		Data             string
+		PrimaryFails     bool
+		Elapsed          time.Duration
+		ExpectedErr      error
+		ExpectedPrimary  string
+		ExpectedFallback string
+	}{
+		{Data: "A", PrimaryFails: true, ExpectedPrimary: "", ExpectedFallback: "A"},
+		{Data: "B", PrimaryFails: false, ExpectedPrimary: "", ExpectedFallback: "AB"},
+		{Data: "C", PrimaryFails: false, Elapsed: time.Minute, ExpectedPrimary: "C", ExpectedFallback: "AB"},
+		{Data: ForcedErrorMessage, PrimaryFails: true, ExpectedErr: forcedError, ExpectedPrimary: "C", ExpectedFallback: "AB"},
+	}
+
+	for _, step := range steps {
+		primary.fail = step.PrimaryFails
+		now = now.Add(step.Elapsed)
+
+		err := d.Dump([]byte(step.Data))
+		if !errors.Is(err, step.ExpectedErr) {
+			t.Errorf("TEST \"FAILOVER DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", step.ExpectedErr, err)
+		}
+
+		givenResult := string((*bytes.Buffer)(&primary.TestDumper).Bytes())
+		if givenResult != step.ExpectedPrimary {
+			t.Errorf("TEST \"FAILOVER DUMPER\" FAILED: EXPECTED PRIMARY DATA %s GOT %s\n", step.ExpectedPrimary, givenResult)
+		}
+
+		givenResult = string((*bytes.Buffer)(fallback).Bytes())
+		if givenResult != step.ExpectedFallback {
+			t.Errorf("TEST \"FAILOVER DUMPER\" FAILED: EXPECTED FALLBACK DATA %s GOT %s\n", step.ExpectedFallback, givenResult)
+		}
+	}
+}
+
+type (
+	FlakyTestDumper struct {
+		TestDumper
+		failures int
+	}
+)
+
+func (d *FlakyTestDumper) Dump(b []byte) error {
+	if d.failures > 0 {
+		d.failures--
+		return forcedError
+	}
+	return d.TestDumper.Dump(b)
+}
+
+func TestRetryDumper(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Failures       int
+		Retryable      func(error) bool
+		ExpectedErr    error
+		ExpectedDelays []time.Duration
+		ExpectedData   string
+	}{
+		{
+			Name:           "SUCCESS AFTER RETRIES",
+			Failures:       2,
+			ExpectedErr:    nil,
+			ExpectedDelays: []time.Duration{time.Second, time.Second * 2},
+			ExpectedData:   "A",
+		},
+		{
+			Name:           "ATTEMPTS EXCEEDED",
+			Failures:       4,
+			ExpectedErr:    forcedError,
+			ExpectedDelays: []time.Duration{time.Second, time.Second * 2, time.Second * 3},
+			ExpectedData:   "",
+		},
+		{
+			Name:     "NOT RETRYABLE",
+			Failures: 1,
+			Retryable: func(err error) bool {
+				return !errors.Is(err, forcedError)
+			},
+			ExpectedErr:    forcedError,
+			ExpectedDelays: nil,
+			ExpectedData:   "",
+		},
+	}
+
+	for _, test := range tests {
+		td := &FlakyTestDumper{failures: test.Failures}
+		d := NewRetryDumper(td, RetryDumperConfig{
+			MaxAttempts:  4,
+			InitialDelay: time.Second,
+			MaxDelay:     time.Second * 3,
+			Retryable:    test.Retryable,
+		})
+
+		var delays []time.Duration
+		d.(*retryDumper).sleep = func(delay time.Duration) {
+			delays = append(delays, delay)
+		}
+
+		err := d.Dump([]byte("A"))
+		if !errors.Is(err, test.ExpectedErr) {
+			t.Errorf("TEST \"RETRY DUMPER %s\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", test.Name, test.ExpectedErr, err)
+		}
+
+		if fmt.Sprint(delays) != fmt.Sprint(test.ExpectedDelays) {
+			t.Errorf("TEST \"RETRY DUMPER %s\" FAILED: EXPECTED DELAYS %v GOT %v\n", test.Name, test.ExpectedDelays, delays)
+		}
+
+		givenResult := string((*bytes.Buffer)(&td.TestDumper).Bytes())
+		if givenResult != test.ExpectedData {
+			t.Errorf("TEST \"RETRY DUMPER %s\" FAILED: EXPECTED DATA %s GOT %s\n", test.Name, test.ExpectedData, givenResult)
+		}
+	}
+}
+
+func TestDeadLetterDumper(t *testing.T) {
+	primary := &SwitchableTestDumper{}
+
+	var deadLetters []string
+	d := NewDeadLetterDumper(primary, DumperFunc(func(b []byte) error {
+		deadLetters = append(deadLetters, string(b))
+		return nil
+	}))
+
+	for _, step := range []struct {
+		Data         string
+		PrimaryFails bool
+	}{
+		{Data: "A", PrimaryFails: false},
+		{Data: "B", PrimaryFails: true},
+		{Data: "C", PrimaryFails: false},
+	} {
+		primary.fail = step.PrimaryFails
+
+		err := d.Dump([]byte(step.Data))
+		if err != nil {
+			t.Errorf("TEST \"DEAD LETTER DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	givenResult := string((*bytes.Buffer)(&primary.TestDumper).Bytes())
+	if givenResult != "AC" {
+		t.Errorf("TEST \"DEAD LETTER DUMPER\" FAILED: EXPECTED PRIMARY DATA %s GOT %s\n", "AC", givenResult)
+	}
+
+	if fmt.Sprint(deadLetters) != "[B]" {
+		t.Errorf("TEST \"DEAD LETTER DUMPER\" FAILED: EXPECTED DEAD LETTERS %s GOT %v\n", "[B]", deadLetters)
+	}
+}
+
+func TestConnDumper(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAILED TO LISTEN: %v\n", err)
+	}
+
+	received := make(chan string)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		b, _ := io.ReadAll(conn)
+		received <- string(b)
+	}()
+
+	d := NewConnDumper(ConnDumperConfig{
+		Network:           "tcp",
+		Address:           ln.Addr().String(),
+		MinReconnectDelay: time.Second,
+	})
+
+	now := time.Now()
+	d.(*connDumper).now = func() time.Time {
+		return now
+	}
+
+	for _, data := range []string{"A", "B"} {
+		err = d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	// The connection is dropped as if it broke, so the listener receives everything written into it.
+	d.(*connDumper).disconnect()
+
+	givenResult := <-received
+	if givenResult != "AB" {
+		t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED DATA %s GOT %s\n", "AB", givenResult)
+	}
+
+	_ = ln.Close()
+
+	err = d.Dump([]byte("C"))
+	if err == nil || errors.Is(err, ErrNotConnected) {
+		t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED DIAL ERROR GOT \"%v\"\n", err)
+	}
+
+	err = d.Dump([]byte("C"))
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrNotConnected, err)
+	}
+
+	now = now.Add(time.Second)
+
+	err = d.Dump([]byte("C"))
+	if err == nil || errors.Is(err, ErrNotConnected) {
+		t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED DIAL ERROR GOT \"%v\"\n", err)
+	}
+
+	err = d.(io.Closer).Close()
+	if err != nil {
+		t.Errorf("TEST \"CONN DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+}
+
+func TestConnDumperDatagrams(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAILED TO LISTEN: %v\n", err)
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	d := NewConnDumper(ConnDumperConfig{
+		Network:         "udp",
+		Address:         pc.LocalAddr().String(),
+		MaxDatagramSize: 4,
+	})
+
+	err = d.Dump([]byte("A\nBC\nDEFGHI\n"))
+	if err != nil {
+		t.Errorf("TEST \"CONN DUMPER DATAGRAMS\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+
+	var datagrams []string
+	for len(datagrams) < 4 {
+		buf := make([]byte, 1<<10)
+
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			break
+		}
+		datagrams = append(datagrams, string(buf[:n]))
+	}
+
+	expected := []string{"A\n", "BC\n", "DEFG", "HI\n"}
+	if fmt.Sprintf("%q", datagrams) != fmt.Sprintf("%q", expected) {
+		t.Errorf("TEST \"CONN DUMPER DATAGRAMS\" FAILED: EXPECTED DATAGRAMS %q GOT %q\n", expected, datagrams)
+	}
+
+	_ = d.(io.Closer).Close()
+}
+
+func TestHTTPDumper(t *testing.T) {
+	var received []string
+	status := http.StatusOK
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+
+		b, _ := io.ReadAll(body)
+		if encoding := r.Header.Get("Content-Encoding"); encoding != "" && encoding != "gzip" {
+			b = append([]byte(encoding+":"), b...)
+		}
+		received = append(received, r.Header.Get("X-Test")+":"+string(b))
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	for _, gz := range []bool{false, true} {
+		received = nil
+		status = http.StatusOK
+
+		d := NewHTTPDumper(HTTPDumperConfig{
+			URL:    srv.URL,
+			Header: http.Header{"X-Test": []string{"T"}},
+			Gzip:   gz,
+		})
+
+		err := d.Dump([]byte("A"))
+		if err != nil {
+			t.Errorf("TEST \"HTTP DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		status = http.StatusServiceUnavailable
+
+		err = d.Dump([]byte("B"))
+		var statusErr *HTTPStatusError
+		if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("TEST \"HTTP DUMPER\" FAILED: EXPECTED STATUS ERROR %d GOT \"%v\"\n", http.StatusServiceUnavailable, err)
+		}
+
+		if fmt.Sprint(received) != "[T:A T:B]" {
+			t.Errorf("TEST \"HTTP DUMPER\" FAILED: EXPECTED REQUESTS %s GOT %v\n", "[T:A T:B]", received)
+		}
+	}
+
+	received = nil
+	status = http.StatusOK
+
+	d := NewHTTPDumper(HTTPDumperConfig{
+		URL:    srv.URL,
+		Header: http.Header{"X-Test": []string{"T"}},
+		Codec:  TestCodec{},
+	})
+
+	err := d.Dump([]byte("A"))
+	if err != nil || fmt.Sprint(received) != "[T:test:<A>]" {
+		t.Errorf("TEST \"HTTP DUMPER\" FAILED: EXPECTED ENCODED REQUEST %s GOT %v \"%v\"\n", "[T:test:<A>]", received, err)
+	}
+}
+
+func TestSyslogDumper(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAILED TO LISTEN: %v\n", err)
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	d := NewSyslogDumper(SyslogDumperConfig{
+		Conn: ConnDumperConfig{
+			Network: "udp",
+			Address: pc.LocalAddr().String(),
+		},
+		Facility: SyslogFacilityLocal0,
+		Severity: SyslogSeverityInfo,
+		Hostname: "host",
+		AppName:  "app",
+	})
+	d.(*syslogDumper).now = func() time.Time {
+		return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	err = d.Dump([]byte("A\nB\n"))
+	if err != nil {
+		t.Errorf("TEST \"SYSLOG DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+
+	for _, data := range []string{"A", "B"} {
+		buf := make([]byte, 1<<10)
+
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Errorf("TEST \"SYSLOG DUMPER\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", err)
+			return
+		}
+
+		expected := fmt.Sprintf("<134>1 2023-01-01T00:00:00.000000Z host app %d - - %s", os.Getpid(), data)
+		if string(buf[:n]) != expected {
+			t.Errorf("TEST \"SYSLOG DUMPER\" FAILED: EXPECTED MESSAGE %s GOT %s\n", expected, buf[:n])
+		}
+	}
+
+	_ = d.(io.Closer).Close()
+}
+
+func TestJournaldDumper(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "journal.sock")
+
+	pc, err := net.ListenPacket("unixgram", socket)
+	if err != nil {
+		t.Fatalf("FAILED TO LISTEN: %v\n", err)
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	d := NewJournaldDumper(JournaldDumperConfig{
+		Socket:      socket,
+		Identifier:  "app",
+		ParsePrefix: true,
+	})
+
+	err = d.Dump([]byte("A\n<3>B\n"))
+	if err != nil {
+		t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+
+	for _, expected := range []string{
+		"SYSLOG_IDENTIFIER=app\nPRIORITY=6\nMESSAGE=A\n",
+		"SYSLOG_IDENTIFIER=app\nPRIORITY=3\nMESSAGE=B\n",
+	} {
+		buf := make([]byte, 1<<10)
+
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", err)
+			return
+		}
+
+		if string(buf[:n]) != expected {
+			t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED MESSAGE %q GOT %q\n", expected, buf[:n])
+		}
+	}
+
+	_ = d.(io.Closer).Close()
+
+	emergency := SyslogSeverityEmergency
+
+	d = NewJournaldDumper(JournaldDumperConfig{
+		Socket:       socket,
+		Priority:     &emergency,
+		MaxEntrySize: 24,
+	})
+
+	err = d.Dump([]byte("ABCDEFGHIJ\n"))
+	if err != nil {
+		t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED DUMP ERROR OF TRUNCATED ENTRY \"nil\" GOT \"%v\"\n", err)
+	}
+
+	buf := make([]byte, 1<<10)
+	n, _, _ := pc.ReadFrom(buf)
+
+	expectedEntry := "PRIORITY=0\nMESSAGE=ABCD\n"
+	if string(buf[:n]) != expectedEntry {
+		t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED TRUNCATED ENTRY %q GOT %q\n", expectedEntry, buf[:n])
+	}
+
+	_ = d.(io.Closer).Close()
+
+	given := string(appendJournaldField(nil, "MESSAGE", []byte("A\nB")))
+	expected := "MESSAGE\n\x03\x00\x00\x00\x00\x00\x00\x00A\nB\n"
+	if given != expected {
+		t.Errorf("TEST \"JOURNALD DUMPER\" FAILED: EXPECTED FIELD %q GOT %q\n", expected, given)
+	}
+}
+
+func TestGELFDumper(t *testing.T) {
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("FAILED TO LISTEN: %v\n", err)
+	}
+	defer func() {
+		_ = pc.Close()
+	}()
+
+	emergency := SyslogSeverityEmergency
+
+	d := NewGELFDumper(GELFDumperConfig{
+		Conn: ConnDumperConfig{
+			Network: "udp",
+			Address: pc.LocalAddr().String(),
+		},
+		Host:      "host",
+		Level:     &emergency,
+		Fields:    map[string]string{"app": "test"},
+		Compress:  true,
+		ChunkSize: 32,
+	})
+	d.(*gelfDumper).now = func() time.Time {
+		return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	err = d.Dump([]byte("A \"quoted\" message\n"))
+	if err != nil {
+		t.Errorf("TEST \"GELF DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = pc.SetReadDeadline(time.Now().Add(time.Second))
+
+	var chunks [][]byte
+	for count := 1; len(chunks) < count; {
+		buf := make([]byte, 1<<10)
+
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("TEST \"GELF DUMPER\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		if !bytes.HasPrefix(buf, gelfChunkMagic) || int(buf[10]) != len(chunks) {
+			t.Fatalf("TEST \"GELF DUMPER\" FAILED: EXPECTED CHUNK %d GOT %q\n", len(chunks), buf[:n])
+		}
+
+		count = int(buf[11])
+		chunks = append(chunks, buf[gelfChunkHeaderSize:n])
+	}
+
+	zr, err := gzip.NewReader(bytes.NewReader(bytes.Join(chunks, nil)))
+	if err != nil {
+		t.Fatalf("TEST \"GELF DUMPER\" FAILED: EXPECTED GZIP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	message, _ := io.ReadAll(zr)
+
+	expected := `{"version":"1.1","host":"host","level":0,"_app":"test","timestamp":1672531200.000000,` +
+		`"short_message":"A \"quoted\" message"}`
+	if string(message) != expected || len(chunks) < 2 {
+		t.Errorf("TEST \"GELF DUMPER\" FAILED: EXPECTED MESSAGE %s IN CHUNKS GOT %s IN %d\n", expected, message, len(chunks))
+	}
+
+	_ = d.(io.Closer).Close()
+
+	d = NewGELFDumper(GELFDumperConfig{
+		Conn: ConnDumperConfig{
+			Network: "udp",
+			Address: pc.LocalAddr().String(),
+		},
+		Host:      "host",
+		ChunkSize: 100,
+	})
+
+	err = d.Dump([]byte(strings.Repeat("ж", 10<<10) + "\n"))
+	if err != nil {
+		t.Errorf("TEST \"GELF DUMPER\" FAILED: EXPECTED DUMP ERROR OF TRUNCATED MESSAGE \"nil\" GOT \"%v\"\n", err)
+	}
+
+	chunks = chunks[:0]
+	for count := 1; len(chunks) < count; {
+		buf := make([]byte, 1<<10)
+
+		n, _, err := pc.ReadFrom(buf)
+		if err != nil {
+			t.Fatalf("TEST \"GELF DUMPER\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		count = int(buf[11])
+		chunks = append(chunks, bytes.Clone(buf[gelfChunkHeaderSize:n]))
+	}
+
+	var truncated struct {
+		ShortMessage string `json:"short_message"`
+	}
+	err = json.Unmarshal(bytes.Join(chunks, nil), &truncated)
+	if err != nil || len(chunks) != gelfMaxChunks || !utf8.ValidString(truncated.ShortMessage) {
+		t.Errorf("TEST \"GELF DUMPER\" FAILED: EXPECTED VALID MESSAGE IN %d CHUNKS GOT \"%v\" IN %d\n",
+			gelfMaxChunks, err, len(chunks))
+	}
+
+	_ = d.(io.Closer).Close()
+}
+
+type (
+	TestObjectStore map[string]string
+
+	// TestCodec encloses payloads in angle brackets.
+	TestCodec struct{}
+)
+
+func (TestCodec) Encode(dst, src []byte) ([]byte, error) {
+	dst = append(dst, '<')
+	dst = append(dst, src...)
+	return append(dst, '>'), nil
+}
+
+func (TestCodec) ContentEncoding() string {
+	return "test"
+}
+
+func (s TestObjectStore) PutObject(_ context.Context, key string, body []byte, contentEncoding string) error {
+	s[key] = contentEncoding + ":" + string(body)
+	return nil
+}
+
+func TestObjectStoreDumper(t *testing.T) {
+	store := TestObjectStore{}
+	d := NewObjectStoreDumper(store, ObjectStoreDumperConfig{
+		KeyTemplate: "logs/2006-01-02/{seq}.log",
+	})
+	d.(*objectStoreDumper).now = func() time.Time {
+		return time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	for _, data := range []string{"A", "B"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"OBJECT STORE DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	expected := "map[logs/2023-01-01/1.log::A logs/2023-01-01/2.log::B]"
+	if fmt.Sprint(store) != expected {
+		t.Errorf("TEST \"OBJECT STORE DUMPER\" FAILED: EXPECTED OBJECTS %s GOT %v\n", expected, store)
+	}
+
+	store = TestObjectStore{}
+	d = NewObjectStoreDumper(store, ObjectStoreDumperConfig{
+		KeyTemplate: "{seq}.log",
+		Codec:       TestCodec{},
+	})
+
+	err := d.Dump([]byte("A"))
+	expected = "map[1.log:test:<A>]"
+	if err != nil || fmt.Sprint(store) != expected {
+		t.Errorf("TEST \"OBJECT STORE DUMPER\" FAILED: EXPECTED ENCODED OBJECTS %s GOT %v \"%v\"\n", expected, store, err)
+	}
+}
+
+func TestWriterDumper(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLogger(1<<3, NewWriterDumper(&buf))
+
+	_, err := fmt.Fprintf(l, "%s", "A")
+	if err != nil {
+		t.Errorf("TEST \"WRITER DUMPER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = l.Close()
+	if err != nil {
+		t.Errorf("TEST \"WRITER DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	if buf.String() != "A" {
+		t.Errorf("TEST \"WRITER DUMPER\" FAILED: EXPECTED DATA %s GOT %s\n", "A", buf.String())
+	}
+}
+
+func TestReopen(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "app.log")
+
+	l := NewLogger(1<<3, NewRotatingFileDumper(RotatingFileDumperConfig{Filename: name}))
+
+	for i, data := range []string{"A", "B"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"REOPEN\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		err = l.DumpBuffer()
+		if err != nil {
+			t.Errorf("TEST \"REOPEN\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		if i == 0 {
+			err = os.Rename(name, name+".old")
+			if err != nil {
+				t.Fatalf("FAILED TO RENAME FILE: %v\n", err)
+			}
+
+			err = l.Reopen()
+			if err != nil {
+				t.Errorf("TEST \"REOPEN\" FAILED: EXPECTED REOPEN ERROR \"nil\" GOT \"%v\"\n", err)
+			}
+		}
+	}
+
+	_ = l.Close()
+
+	for file, data := range map[string]string{name + ".old": "A", name: "B"} {
+		given := readFile(t, file)
+		if given != data {
+			t.Errorf("TEST \"REOPEN\" FAILED: EXPECTED FILE \"%s\" DATA %s GOT %s\n", file, data, given)
+		}
+	}
+}
+
+func TestCompressingDumper(t *testing.T) {
+	codec, err := NewGzipCodec(gzip.BestSpeed)
+	if err != nil {
+		t.Fatalf("TEST \"COMPRESSING DUMPER\" FAILED: EXPECTED CODEC ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, err = NewGzipCodec(gzip.BestCompression + 1)
+	if err == nil {
+		t.Errorf("TEST \"COMPRESSING DUMPER\" FAILED: EXPECTED CODEC ERROR FOR INVALID LEVEL GOT \"nil\"\n")
+	}
+
+	var payloads []string
+	d := NewCompressingDumper(DumperFunc(func(b []byte) error {
+		zr, err := gzip.NewReader(bytes.NewReader(b))
+		if err != nil {
+			return err
+		}
+
+		data, err := io.ReadAll(zr)
+		payloads = append(payloads, string(data))
+		return err
+	}), codec)
+
+	for _, data := range []string{"A", "BC"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"COMPRESSING DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	if fmt.Sprint(payloads) != "[A BC]" {
+		t.Errorf("TEST \"COMPRESSING DUMPER\" FAILED: EXPECTED PAYLOADS %s GOT %s\n", "[A BC]", payloads)
+	}
+}
+
+func TestEncryptingDumper(t *testing.T) {
+	key := StaticKey(bytes.Repeat([]byte{1}, 32))
+
+	var sealed bytes.Buffer
+	d := NewEncryptingDumper(NewWriterDumper(&sealed), key)
+
+	for _, data := range []string{"A", "BC"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"ENCRYPTING DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	if bytes.Contains(sealed.Bytes(), []byte("BC")) {
+		t.Errorf("TEST \"ENCRYPTING DUMPER\" FAILED: EXPECTED SEALED DATA GOT %q\n", sealed.Bytes())
+	}
+
+	tests := []struct {
+		Name             string
+		Key              KeyProvider
+		Opts             []FrameReaderOption
+		ExpectedPayloads string
+		ExpectedErr      error
+	}{
+		{Name: "VALID KEY", Key: key, ExpectedPayloads: "[A BC]", ExpectedErr: io.EOF},
+		{Name: "WRONG KEY", Key: StaticKey(bytes.Repeat([]byte{2}, 32)), ExpectedPayloads: "[]", ExpectedErr: ErrCorruptFrame},
+		{Name: "MAX FRAME SIZE", Key: key, Opts: []FrameReaderOption{WithMaxFrameSize(29)}, ExpectedPayloads: "[A]", ExpectedErr: ErrCorruptFrame},
+	}
+
+	for _, test := range tests {
+		r := NewDecryptingReader(bytes.NewReader(sealed.Bytes()), test.Key, test.Opts...)
+
+		var payloads []string
+		var err error
+		for {
+			var payload []byte
+			payload, err = r.Next()
+			if err != nil {
+				break
+			}
+			payloads = append(payloads, string(payload))
+		}
+
+		if fmt.Sprint(payloads) != test.ExpectedPayloads || !errors.Is(err, test.ExpectedErr) {
+			t.Errorf("TEST \"ENCRYPTING DUMPER %s\" FAILED: EXPECTED %s \"%v\" GOT %s \"%v\"\n",
+				test.Name, test.ExpectedPayloads, test.ExpectedErr, payloads, err)
+		}
+	}
+}
+
+func TestDumperClosed(t *testing.T) {
+	dir := t.TempDir()
+
+	dumpers := map[string]Dumper{
+		"ROTATING": NewRotatingFileDumper(RotatingFileDumperConfig{Filename: filepath.Join(dir, "app.log")}),
+		"TIMED":    NewTimedFileDumper(TimedFileDumperConfig{Pattern: filepath.Join(dir, "2006-01-02.log")}),
+		"CONN":     NewConnDumper(ConnDumperConfig{Network: "udp", Address: "127.0.0.1:9"}),
+		"SPOOLING": NewSpoolingDumper(&TestDumper{}, SpoolingDumperConfig{Dir: filepath.Join(dir, "spool")}),
+	}
+
+	for name, d := range dumpers {
+		err := d.(io.Closer).Close()
+		if err != nil {
+			t.Errorf("TEST \"DUMPER CLOSED %s\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", name, err)
+		}
+
+		err = d.Dump([]byte("A"))
+		if !errors.Is(err, ErrDumperClosed) {
+			t.Errorf("TEST \"DUMPER CLOSED %s\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", name, ErrDumperClosed, err)
+		}
+	}
+}
+
+func TestRoutingDumper(t *testing.T) {
+	errorsDumper, allDumper, restDumper := &TestDumper{}, &TestDumper{}, &TestDumper{}
+
+	l := NewLogger(1<<10, NewRoutingDumper(
+		Route{Name: "errors", Match: PrefixMatch("E "), Dumper: errorsDumper, Continue: true},
+		Route{Name: "debug", Match: ContainsMatch("debug"), Dumper: DumperFunc(func([]byte) error { return nil })},
+		Route{Name: "all", Dumper: allDumper, Continue: true},
+		Route{Name: "rest", Dumper: restDumper},
+	))
+
+	for _, data := range []string{"I a\n", "E b\n", "D debug\n"} {
+		_, _ = l.Write([]byte(data))
+	}
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"ROUTING DUMPER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	for _, test := range []struct {
+		Name     string
+		Dumper   *TestDumper
+		Expected string
+	}{
+		{Name: "ERRORS", Dumper: errorsDumper, Expected: "E b\n"},
+		{Name: "ALL", Dumper: allDumper, Expected: "I a\nE b\n"},
+		{Name: "REST", Dumper: restDumper, Expected: "I a\nE b\n"},
+	} {
+		givenResult := string((*bytes.Buffer)(test.Dumper).Bytes())
+		if givenResult != test.Expected {
+			t.Errorf("TEST \"ROUTING DUMPER %s\" FAILED: EXPECTED DATA %q GOT %q\n", test.Name, test.Expected, givenResult)
+		}
+	}
+}
+
+func TestFaultyDumper(t *testing.T) {
+	var b bytes.Buffer
+	td := (*TestDumper)(&b)
+	d := NewFaultyDumper(td, FaultyDumperConfig{ErrorProbability: 1, Latency: UniformLatency(time.Second, time.Second*2)})
+
+	var slept time.Duration
+	d.(*faultyDumper).sleep = func(d time.Duration) {
+		slept += d
+	}
+
+	err := d.Dump([]byte("ABCD"))
+	if !errors.Is(err, ErrInjected) || b.Len() != 0 {
+		t.Errorf("TEST \"FAULTY DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" WITHOUT DATA GOT \"%v\" WITH %q\n", ErrInjected, err, b.String())
+	}
+
+	if slept < time.Second || slept >= time.Second*2 {
+		t.Errorf("TEST \"FAULTY DUMPER\" FAILED: EXPECTED LATENCY IN [%v, %v) GOT %v\n", time.Second, time.Second*2, slept)
+	}
+
+	d = NewFaultyDumper(td, FaultyDumperConfig{PartialWriteProbability: 1, Err: forcedError, Seed: 1})
+
+	err = d.Dump([]byte("ABCD"))
+	if !errors.Is(err, forcedError) || b.Len() >= 4 || !bytes.HasPrefix([]byte("ABCD"), b.Bytes()) {
+		t.Errorf("TEST \"FAULTY DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" WITH PARTIAL DATA GOT \"%v\" WITH %q\n", forcedError, err, b.String())
+	}
+
+	b.Reset()
+	d = NewFaultyDumper(td, FaultyDumperConfig{})
+
+	err = d.Dump([]byte("ABCD"))
+	if err != nil || b.String() != "ABCD" {
+		t.Errorf("TEST \"FAULTY DUMPER\" FAILED: EXPECTED DATA %q GOT %q WITH ERROR \"%v\"\n", "ABCD", b.String(), err)
+	}
+}
+
+func TestSpoolingDumper(t *testing.T) {
+	dir := t.TempDir()
+	primary := &SwitchableTestDumper{fail: true}
+
+	d := NewSpoolingDumper(primary, SpoolingDumperConfig{Dir: dir, MaxSize: 4, DrainInterval: time.Hour})
+
+	for _, data := range []string{"AB", "CD"} {
+		err := d.Dump([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := d.Dump([]byte("EF"))
+	if !errors.Is(err, ErrSpoolFull) {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", ErrSpoolFull, err)
+	}
+
+	primary.fail = false
+
+	err = d.Dump([]byte("EF"))
+	if !errors.Is(err, ErrSpoolFull) {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR \"%v\" WHILE SPOOL IS NOT EMPTY GOT \"%v\"\n", ErrSpoolFull, err)
+	}
+
+	err = d.(*spoolingDumper).drain()
+	if err != nil {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DRAIN ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = d.Dump([]byte("EF"))
+	if err != nil {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = d.(io.Closer).Close()
+
+	givenResult := string((*bytes.Buffer)(&primary.TestDumper).Bytes())
+	if givenResult != "ABCDEF" {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DATA %s GOT %s\n", "ABCDEF", givenResult)
+	}
+
+	names, _ := filepath.Glob(filepath.Join(dir, "*"))
+	if len(names) != 0 {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED EMPTY SPOOL GOT %v\n", names)
+	}
+
+	primary.fail = true
+	d = NewSpoolingDumper(primary, SpoolingDumperConfig{Dir: dir, DrainInterval: time.Hour})
+	_ = d.Dump([]byte("GH"))
+	primary.fail = false
+	_ = d.(io.Closer).Close()
+
+	givenResult = string((*bytes.Buffer)(&primary.TestDumper).Bytes())
+	if givenResult != "ABCDEFGH" {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DATA %s AFTER CLOSE GOT %s\n", "ABCDEFGH", givenResult)
+	}
+
+	notDir := filepath.Join(dir, "file")
+	_ = os.WriteFile(notDir, nil, 0600)
+
+	d = NewSpoolingDumper(primary, SpoolingDumperConfig{Dir: filepath.Join(notDir, "spool"), DrainInterval: time.Hour})
+
+	err = d.Dump([]byte("IJ"))
+	if err != nil {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR \"nil\" WITHOUT SPOOL GOT \"%v\"\n", err)
+	}
+
+	primary.fail = true
+	err = d.Dump([]byte("KL"))
+	if err == nil {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DUMP ERROR WITHOUT SPOOL GOT \"nil\"\n")
+	}
+	primary.fail = false
+	_ = d.(io.Closer).Close()
+
+	givenResult = string((*bytes.Buffer)(&primary.TestDumper).Bytes())
+	if givenResult != "ABCDEFGHIJ" {
+		t.Errorf("TEST \"SPOOLING DUMPER\" FAILED: EXPECTED DATA %s WITHOUT SPOOL GOT %s\n", "ABCDEFGHIJ", givenResult)
+	}
+}
@@ -0,0 +1,154 @@
+package alslgr
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+type (
+	TimedFileDumperConfig struct {
+		Pattern   string
+		Perms     os.FileMode
+		Retention time.Duration
+
+		OnCleanupError func(err error)
+	}
+
+	timedFileDumper struct {
+		mx sync.Mutex
+
+		config TimedFileDumperConfig
+		dir    string
+		layout string
+
+		file     *os.File
+		filename string
+		closed   bool
+
+		now    func() time.Time
+		remove func(name string) error
+	}
+)
+
+// NewTimedFileDumper creates a Dumper writing into a file whose base name is the Pattern formatted as a time layout,
+// e.g. "/var/log/app-2006-01-02.log" gives one file per day. When Retention is positive, files matching the
+// Pattern that are older than Retention are removed every time a new file is started, except the started one. Removal
+// happens after the dump is written and does not fail it, its errors are passed to OnCleanupError if it is not nil.
+func NewTimedFileDumper(config TimedFileDumperConfig) Dumper {
+	if config.Perms == 0 {
+		config.Perms = FileDumperDefaultPerms
+	}
+
+	return &timedFileDumper{
+		config: config,
+		dir:    filepath.Dir(config.Pattern),
+		layout: filepath.Base(config.Pattern),
+		now:    time.Now,
+		remove: removeIfExists,
+	}
+}
+
+func (d *timedFileDumper) Dump(b []byte) error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	if d.closed {
+		return ErrDumperClosed
+	}
+
+	now := d.now()
+
+	filename := filepath.Join(d.dir, now.Format(d.layout))
+	switched := d.file == nil || filename != d.filename
+	if switched {
+		err := d.switchFile(filename)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := d.file.Write(b)
+
+	if switched && d.config.Retention > 0 {
+		cleanupErr := d.cleanup(now.Add(-d.config.Retention))
+		if cleanupErr != nil && d.config.OnCleanupError != nil {
+			d.config.OnCleanupError(cleanupErr)
+		}
+	}
+
+	return err
+}
+
+// Reopen closes the file, so it is opened again by the next dump.
+func (d *timedFileDumper) Reopen() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	return d.closeFile()
+}
+
+func (d *timedFileDumper) Close() error {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+
+	d.closed = true
+	return d.closeFile()
+}
+
+func (d *timedFileDumper) closeFile() error {
+	if d.file == nil {
+		return nil
+	}
+
+	err := d.file.Close()
+	d.file = nil
+	return err
+}
+
+func (d *timedFileDumper) switchFile(filename string) error {
+	if d.file != nil {
+		err := d.file.Close()
+		d.file = nil
+		if err != nil {
+			return err
+		}
+	}
+
+	f, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_WRONLY, d.config.Perms)
+	if err != nil {
+		return err
+	}
+
+	d.file = f
+	d.filename = filename
+
+	return nil
+}
+
+func (d *timedFileDumper) cleanup(threshold time.Time) error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		name := filepath.Join(d.dir, entry.Name())
+		if entry.IsDir() || name == d.filename {
+			continue
+		}
+
+		t, err := time.ParseInLocation(d.layout, entry.Name(), threshold.Location())
+		if err != nil || !t.Before(threshold) {
+			continue
+		}
+
+		err = d.remove(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package alslgr
+
+import (
+	"io"
+)
+
+type (
+	writerDumper struct {
+		writer io.Writer
+	}
+)
+
+// NewWriterDumper creates a Dumper writing dumps into w. Closing the Dumper closes w if it implements io.Closer.
+func NewWriterDumper(w io.Writer) Dumper {
+	return &writerDumper{
+		writer: w,
+	}
+}
+
+func (d *writerDumper) Dump(b []byte) error {
+	n, err := d.writer.Write(b)
+	if err == nil && n < len(b) {
+		err = io.ErrShortWrite
+	}
+	return err
+}
+
+func (d *writerDumper) ReadFrom(r io.Reader) (int64, error) {
+	return io.Copy(d.writer, r)
+}
+
+func (d *writerDumper) Close() error {
+	closer, ok := d.writer.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
@@ -0,0 +1,40 @@
+package alslgr
+
+import (
+	"errors"
+	"fmt"
+)
+
+type (
+	// DumpError is returned by a Logger when its Dumper fails to dump N bytes.
+	DumpError struct {
+		N      int
+		Dumper string
+		Err    error
+	}
+)
+
+var (
+	ErrLoggerClosed = errors.New("logger is closed")
+	ErrBufferFull   = errors.New("buffer is full")
+	ErrNotConnected = errors.New("not connected")
+	ErrCorruptFrame = errors.New("corrupt frame")
+	ErrDumpTimeout  = errors.New("dump timed out")
+	ErrDumperClosed = errors.New("dumper is closed")
+	ErrLoggerExists = errors.New("logger already exists")
+	ErrInjected     = errors.New("injected fault")
+
+	ErrInvalidSchedule = errors.New("invalid schedule")
+	ErrMessageTooLarge = errors.New("message too large")
+	ErrRecordTooLarge  = errors.New("record too large")
+	ErrSpoolFull       = errors.New("spool is full")
+	ErrNotAppendOnly   = errors.New("file is not opened in append mode")
+)
+
+func (e *DumpError) Error() string {
+	return fmt.Sprintf("dump of %d bytes to %s: %v", e.N, e.Dumper, e.Err)
+}
+
+func (e *DumpError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,12 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package alslgr
+
+import (
+	"errors"
+	"os"
+)
+
+func lockFile(_ *os.File) error {
+	return errors.ErrUnsupported
+}
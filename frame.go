@@ -0,0 +1,124 @@
+package alslgr
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+	"slices"
+)
+
+type (
+	FrameReaderOption func(o *frameReaderOptions)
+
+	frameReaderOptions struct {
+		maxFrameSize int
+	}
+
+	frameReader struct {
+		r            *bufio.Reader
+		maxFrameSize int
+		header       [frameHeaderSize]byte
+		record       []byte
+	}
+)
+
+const (
+	FrameReaderDefaultMaxFrameSize = 64 << 20
+
+	// frameChunkSize is the size a frame buffer grows by at most while the frame is read, so a corrupt length does not
+	// allocate more memory than the input holds.
+	frameChunkSize = 64 << 10
+
+	// frameHeaderSize is the size of a frame header holding the length and the CRC32-C checksum of the record, both
+	// big-endian.
+	frameHeaderSize = 8
+)
+
+var (
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// WithMaxFrameSize makes a FrameReader return ErrCorruptFrame for frames longer than size bytes, which is
+// FrameReaderDefaultMaxFrameSize by default.
+func WithMaxFrameSize(size int) FrameReaderOption {
+	return func(o *frameReaderOptions) {
+		o.maxFrameSize = size
+	}
+}
+
+func newFrameReaderOptions(opts []FrameReaderOption) frameReaderOptions {
+	o := frameReaderOptions{
+		maxFrameSize: FrameReaderDefaultMaxFrameSize,
+	}
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// NewFrameReader creates a FrameReader parsing records dumped by a Logger with WithFraming.
+func NewFrameReader(r io.Reader, opts ...FrameReaderOption) FrameReader {
+	return &frameReader{
+		r:            bufio.NewReader(r),
+		maxFrameSize: newFrameReaderOptions(opts).maxFrameSize,
+	}
+}
+
+// Next returns the next record, which is valid until the following call. It returns io.EOF once there are no more
+// records, io.ErrUnexpectedEOF if the last frame is truncated and ErrCorruptFrame if the checksum does not match or the
+// length exceeds the maximum frame size.
+func (r *frameReader) Next() ([]byte, error) {
+	_, err := io.ReadFull(r.r, r.header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(r.header[:4])
+	if uint64(size) > uint64(r.maxFrameSize) {
+		return nil, ErrCorruptFrame
+	}
+
+	r.record, err = readFrame(r.r, r.record, int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	if crc32.Checksum(r.record, crcTable) != binary.BigEndian.Uint32(r.header[4:]) {
+		return nil, ErrCorruptFrame
+	}
+
+	return r.record, nil
+}
+
+// readFrame reads size bytes into buf, growing it by at most frameChunkSize at a time.
+func readFrame(r io.Reader, buf []byte, size int) ([]byte, error) {
+	buf = buf[:0]
+
+	for len(buf) < size {
+		n := min(size-len(buf), frameChunkSize)
+		buf = slices.Grow(buf, n)
+
+		read, err := io.ReadFull(r, buf[len(buf):len(buf)+n])
+		buf = buf[:len(buf)+read]
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return buf, err
+		}
+	}
+
+	return buf, nil
+}
+
+// putFrameHeader fills the frame header reserved at the beginning of frame for the record following it.
+func putFrameHeader(frame []byte) {
+	record := frame[frameHeaderSize:]
+
+	binary.BigEndian.PutUint32(frame[:4], uint32(len(record)))
+	binary.BigEndian.PutUint32(frame[4:frameHeaderSize], crc32.Checksum(record, crcTable))
+}
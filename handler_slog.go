@@ -0,0 +1,58 @@
+package alslgr
+
+import (
+	"log/slog"
+)
+
+type (
+	SlogFormat int
+
+	slogHandler struct {
+		slog.Handler
+
+		logger Logger
+	}
+)
+
+const (
+	SlogFormatText SlogFormat = iota
+	SlogFormatJSON
+)
+
+// NewSlogHandler creates a slog.Handler encoding records in the given format and writing them into the Logger, one
+// Write per record. Flush dumps the buffer of the Logger and Close closes it.
+func NewSlogHandler(logger Logger, format SlogFormat, opts *slog.HandlerOptions) SlogHandler {
+	var h slog.Handler
+	if format == SlogFormatJSON {
+		h = slog.NewJSONHandler(logger, opts)
+	} else {
+		h = slog.NewTextHandler(logger, opts)
+	}
+
+	return &slogHandler{
+		Handler: h,
+		logger:  logger,
+	}
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogHandler{
+		Handler: h.Handler.WithAttrs(attrs),
+		logger:  h.logger,
+	}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	return &slogHandler{
+		Handler: h.Handler.WithGroup(name),
+		logger:  h.logger,
+	}
+}
+
+func (h *slogHandler) Flush() error {
+	return h.logger.DumpBuffer()
+}
+
+func (h *slogHandler) Close() error {
+	return h.logger.Close()
+}
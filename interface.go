@@ -2,18 +2,159 @@ package alslgr
 
 import (
 	"context"
+	"io"
+	"log/slog"
+	"os"
 	"time"
 )
 
 type (
 	Logger interface {
 		Write(message []byte) (int, error)
+		WriteString(message string) (int, error)
+		WriteV(fragments ...[]byte) (int, error)
+		WriteByte(c byte) error
+		ReadFrom(r io.Reader) (int64, error)
+		WriteNow(message []byte) (int, error)
 
 		DumpBuffer() error
-		AutoDumpBuffer(interval time.Duration) (<-chan error, context.CancelFunc)
+		DumpBufferContext(ctx context.Context) error
+		AutoDumpBuffer(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc)
+		AutoDumpBufferContext(ctx context.Context, interval time.Duration, opts ...AutoDumpOption) <-chan error
+		Sync() error
+
+		WriteTo(w io.Writer) (int64, error)
+		PendingBytes() int
+		Peek() []byte
+		Len() int
+		Cap() int
+		Stats() Stats
+		Healthy() bool
+		LastDumpError() (error, time.Time)
+
+		SetDumper(dumper Dumper) error
+		SetContextDumper(dumper ContextDumper) error
+		SetCapacity(capacity int) error
+
+		FlushOnSignal(ctx context.Context, signals ...os.Signal) <-chan error
+		Reopen() error
+		ReopenOnSignal(ctx context.Context, signals ...os.Signal) <-chan error
+		Close() error
+	}
+
+	Manager interface {
+		Register(name string, logger Logger) error
+		Logger(name string) Logger
+
+		DumpAll() error
+		DumpAllContext(ctx context.Context) error
+		AutoDumpAll(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc)
+
+		CloseAll() error
+	}
+
+	// Buffer batches records of type T the way a Logger batches bytes.
+	Buffer[T any] interface {
+		Write(record T) error
+
+		DumpBuffer() error
+		DumpBufferContext(ctx context.Context) error
+		AutoDumpBuffer(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc)
+
+		Len() int
+		Close() error
+	}
+
+	// Schedule defines times of auto dumps, Next returns the first one after t or the zero time if there is none.
+	Schedule interface {
+		Next(t time.Time) time.Time
+	}
+
+	// Budget is a limit of buffered data shared by loggers. It can only be created with NewBudget.
+	Budget interface {
+		Used() int
+		Limit() int
+
+		reserve(n int) bool
+		add(n int)
+	}
+
+	RecordDumper[T any] interface {
+		DumpRecords(records []T) error
 	}
 
 	Dumper interface {
 		Dump([]byte) error
 	}
+
+	ContextDumper interface {
+		Dump(ctx context.Context, b []byte) error
+	}
+
+	// BatchDumper is implemented by dumpers that need record boundaries. If the Dumper of a Logger implements it,
+	// DumpMany is called with the buffered records instead of Dump.
+	BatchDumper interface {
+		DumpMany(records [][]byte) error
+	}
+
+	// ReopenableDumper is implemented by dumpers holding files open, Reopen makes them start writing into a freshly
+	// opened file, e.g. after the old one has been moved by logrotate.
+	ReopenableDumper interface {
+		Reopen() error
+	}
+
+	// MetricsRecorder receives logger events as they happen, so they can be exported to a metrics system. Methods are
+	// called synchronously and must not block.
+	MetricsRecorder interface {
+		RecordBuffered(bytes int)
+		RecordDump(bytes int, duration time.Duration, err error)
+		RecordDropped(records, bytes int)
+	}
+
+	// Codec compresses dump payloads. Encode appends the encoded src to dst, ContentEncoding returns the name of the
+	// encoding as used in HTTP, e.g. "gzip" or "zstd".
+	Codec interface {
+		Encode(dst, src []byte) ([]byte, error)
+		ContentEncoding() string
+	}
+
+	// KeyProvider returns the AES key used to seal and open payloads, 16, 24 or 32 bytes long.
+	KeyProvider interface {
+		Key() ([]byte, error)
+	}
+
+	FrameReader interface {
+		Next() ([]byte, error)
+	}
+
+	WriteSyncer interface {
+		io.Writer
+
+		Sync() error
+	}
+
+	// LeveledLogger is a leveled API over a Logger. Key-value variants take alternating keys and values, as slog does.
+	LeveledLogger interface {
+		Debug(msg string, kv ...any)
+		Info(msg string, kv ...any)
+		Warn(msg string, kv ...any)
+		Error(msg string, kv ...any)
+
+		Debugf(format string, args ...any)
+		Infof(format string, args ...any)
+		Warnf(format string, args ...any)
+		Errorf(format string, args ...any)
+
+		With(kv ...any) LeveledLogger
+
+		Flush() error
+		Close() error
+	}
+
+	SlogHandler interface {
+		slog.Handler
+
+		Flush() error
+		Close() error
+	}
 )
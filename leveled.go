@@ -0,0 +1,108 @@
+package alslgr
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"time"
+)
+
+type (
+	leveledLogger struct {
+		handler SlogHandler
+	}
+)
+
+// NewLeveledLogger creates a LeveledLogger encoding records in the given format and writing them into the Logger.
+// Records below level are discarded without being formatted.
+func NewLeveledLogger(logger Logger, format SlogFormat, level slog.Leveler) LeveledLogger {
+	return &leveledLogger{
+		handler: NewSlogHandler(logger, format, &slog.HandlerOptions{
+			Level: level,
+		}),
+	}
+}
+
+func (l *leveledLogger) Debug(msg string, kv ...any) {
+	l.log(slog.LevelDebug, msg, kv)
+}
+
+func (l *leveledLogger) Info(msg string, kv ...any) {
+	l.log(slog.LevelInfo, msg, kv)
+}
+
+func (l *leveledLogger) Warn(msg string, kv ...any) {
+	l.log(slog.LevelWarn, msg, kv)
+}
+
+func (l *leveledLogger) Error(msg string, kv ...any) {
+	l.log(slog.LevelError, msg, kv)
+}
+
+func (l *leveledLogger) Debugf(format string, args ...any) {
+	l.logf(slog.LevelDebug, format, args)
+}
+
+func (l *leveledLogger) Infof(format string, args ...any) {
+	l.logf(slog.LevelInfo, format, args)
+}
+
+func (l *leveledLogger) Warnf(format string, args ...any) {
+	l.logf(slog.LevelWarn, format, args)
+}
+
+func (l *leveledLogger) Errorf(format string, args ...any) {
+	l.logf(slog.LevelError, format, args)
+}
+
+func (l *leveledLogger) With(kv ...any) LeveledLogger {
+	r := slog.NewRecord(time.Time{}, 0, "", 0)
+	r.Add(kv...)
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+
+	handler, _ := l.handler.WithAttrs(attrs).(SlogHandler)
+
+	return &leveledLogger{
+		handler: handler,
+	}
+}
+
+func (l *leveledLogger) Flush() error {
+	return l.handler.Flush()
+}
+
+func (l *leveledLogger) Close() error {
+	return l.handler.Close()
+}
+
+func (l *leveledLogger) log(level slog.Level, msg string, kv []any) {
+	if !l.handler.Enabled(context.Background(), level) {
+		return
+	}
+
+	r := l.record(level, msg)
+	r.Add(kv...)
+	_ = l.handler.Handle(context.Background(), r)
+}
+
+func (l *leveledLogger) logf(level slog.Level, format string, args []any) {
+	if !l.handler.Enabled(context.Background(), level) {
+		return
+	}
+
+	_ = l.handler.Handle(context.Background(), l.record(level, fmt.Sprintf(format, args...)))
+}
+
+// record creates a record pointing to the caller of an exported method, skipping runtime.Callers, record and log.
+func (l *leveledLogger) record(level slog.Level, msg string) slog.Record {
+	var pcs [1]uintptr
+	runtime.Callers(4, pcs[:])
+
+	return slog.NewRecord(time.Now(), level, msg, pcs[0])
+}
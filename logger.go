@@ -1,85 +1,1193 @@
 package alslgr
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 )
 
 type (
+	queuedRecord struct {
+		data   []byte
+		header int
+
+		// barrier is closed by the worker instead of writing the record.
+		barrier chan struct{}
+	}
+
+	// pendingDump is a dump call abandoned by withDumpTimeout, err is set once done is closed.
+	pendingDump struct {
+		done chan struct{}
+		err  error
+	}
+
 	logger struct {
 		mx sync.Mutex
 
-		buffer   []byte
-		length   int
-		capacity int
+		shards        []*shard
+		spares        []*segment
+		sparesLen     atomic.Int64
+		shardCount    int
+		orderedShards bool
+		stickyShard   atomic.Uint32
+		seq           atomic.Uint64
+		payload       []byte
+		batch         [][]byte
+		buffers       [][]byte
+		capacity      int
+
+		overflowPolicy OverflowPolicy
+		discardOnError bool
+
+		maxRetainedSize int
+
+		flushThreshold int
+		flushCh        chan struct{}
+
+		idleFlush time.Duration
+		lastWrite atomic.Int64
+
+		delimited       bool
+		recordDelimiter byte
+
+		framed          bool
+		stampSeq        bool
+		timestampLayout string
+		prefix          string
+		middlewares     []WriteMiddleware
+		maxRecordSize   int
+		truncateMarker  string
+		repeatFormat    string
+		dropFormat      string
+		sampler         *sampler
+		sealSegment     func(*segment)
+
+		unreportedDrops atomic.Int64
+
+		budget Budget
 
-		dumper Dumper
+		wal *writeAheadLog
+
+		dumpTimeout     time.Duration
+		abandoned       *pendingDump
+		sparesAbandoned bool
+
+		queueMx     sync.RWMutex
+		queue       chan *queuedRecord
+		queueClosed bool
+		records     sync.Pool
+		asyncErr    error
+
+		dumper      ContextDumper
+		dumperName  string
+		batchDumper BatchDumper
+		readerFrom  io.ReaderFrom
+
+		stats              stats
+		metrics            MetricsRecorder
+		unhealthyThreshold int
+
+		onDumpError func(err error, bytes int)
+		onFlush     func(bytes int)
+
+		ctx     context.Context
+		cancel  context.CancelFunc
+		workers sync.WaitGroup
+		closed  atomic.Bool
 	}
 )
 
-func NewLogger(capacity int, dumper Dumper) Logger {
-	return &logger{
-		mx:       sync.Mutex{},
-		buffer:   make([]byte, capacity),
-		capacity: capacity,
-		dumper:   dumper,
+var (
+	// byteRecords holds every byte value at its index, so WriteByte can write c without allocating a slice for it.
+	// Records are copied before they reach the Dumper, so they are never modified.
+	byteRecords = func() (b [256]byte) {
+		for i := range b {
+			b[i] = byte(i)
+		}
+		return b
+	}()
+)
+
+const (
+	// headerBufferSize is the size of a stack buffer the record header is formatted into, a longer header is allocated.
+	headerBufferSize = 64
+	// readFromBufferSize is the size of a buffer ReadFrom reads into, a longer line is split into several records.
+	readFromBufferSize = 32 << 10
+)
+
+func NewLogger(capacity int, dumper Dumper, opts ...Option) Logger {
+	return NewContextLogger(capacity, NewContextDumperAdapter(dumper), opts...)
+}
+
+func NewContextLogger(capacity int, dumper ContextDumper, opts ...Option) Logger {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	l := &logger{
+		mx:         sync.Mutex{},
+		shardCount: 1,
+		capacity:   capacity,
+		ctx:        ctx,
+		cancel:     cancel,
+
+		unhealthyThreshold: 1,
+	}
+
+	for _, opt := range opts {
+		opt(l)
+	}
+
+	l.setDumper(dumper)
+
+	if l.delimited {
+		l.shardCount = 1
+		l.repeatFormat = ""
+		l.dropFormat = ""
+		l.framed = false
+		l.stampSeq = false
+	}
+
+	if l.stampSeq {
+		l.orderedShards = true
+	}
+
+	if l.repeatFormat != "" {
+		l.sealSegment = l.appendRepeats
+	}
+
+	if l.maxRecordSize > 0 {
+		l.middlewares = append(l.middlewares, l.limitRecordSize)
+	}
+
+	shardCapacity := max(capacity/l.shardCount, 1)
+
+	l.shards = make([]*shard, l.shardCount)
+	l.spares = make([]*segment, l.shardCount)
+	for i := range l.shards {
+		l.shards[i] = newShard(shardCapacity)
+		l.spares[i] = newSegment(shardCapacity)
+	}
+
+	if l.queue != nil {
+		l.workers.Add(1)
+		go l.asyncWorker()
+	}
+
+	if l.flushThreshold > 0 {
+		l.flushThreshold = max(l.flushThreshold/l.shardCount, 1)
+		l.flushCh = make(chan struct{}, 1)
+
+		l.workers.Add(1)
+		go l.flushWorker()
+	}
+
+	if l.idleFlush > 0 {
+		l.workers.Add(1)
+		go l.idleWorker()
+	}
+
+	if l.reportsSampled() {
+		l.workers.Add(1)
+		go l.samplerWorker()
 	}
+
+	return l
 }
 
 func (l *logger) Write(b []byte) (int, error) {
-	l.mx.Lock()
-	defer l.mx.Unlock()
+	if len(l.middlewares) == 0 {
+		return l.writeRecord(b)
+	}
+
+	record, err := l.applyMiddlewares(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if record == nil {
+		return len(b), nil
+	}
+
+	n, err := l.writeRecord(record)
+	if n == 0 && err != nil {
+		return 0, err
+	}
+
+	return len(b), err
+}
+
+// WriteString writes s as Write does, without copying it into a byte slice first.
+func (l *logger) WriteString(s string) (int, error) {
+	// Records are copied before they reach the Dumper, so s can be written as is.
+	return l.Write(unsafe.Slice(unsafe.StringData(s), len(s)))
+}
+
+// WriteV writes fragments bs, e.g. a prefix, a message and a newline, or net.Buffers, as a single record. Fragments
+// are joined in a pooled buffer, so the call site does not have to allocate one. Passing an existing slice as bs...
+// avoids allocating the variadic argument.
+func (l *logger) WriteV(bs ...[]byte) (int, error) {
+	record := l.getRecord()
+	defer l.putRecord(record)
+
+	for _, b := range bs {
+		record.data = append(record.data, b...)
+	}
 
-	return len(b), l.write(b)
+	return l.Write(record.data)
 }
 
-func (l *logger) write(b []byte) error {
-	bLen := len(b)
+// WriteByte writes c as a record of its own, which is mostly useful with WithRecordDelimiter.
+func (l *logger) WriteByte(c byte) error {
+	_, err := l.Write(byteRecords[c : c+1 : c+1])
+	return err
+}
 
-	if bLen > l.capacity {
-		return l.dumper.Dump(b)
+// ReadFrom writes data read from r until EOF, every line terminated by the record delimiter, '\n' by default, as a
+// separate record. A line longer than the read buffer is split, data left after the last delimiter is a record too.
+func (l *logger) ReadFrom(r io.Reader) (int64, error) {
+	delim := byte('\n')
+	if l.delimited {
+		delim = l.recordDelimiter
 	}
 
-	if l.capacity-l.length < bLen {
-		err := l.dump()
+	buf := make([]byte, readFromBufferSize)
+
+	var n int64
+	var pending int
+	for {
+		m, err := r.Read(buf[pending:])
+		n += int64(m)
+
+		data := buf[:pending+m]
+		for {
+			i := bytes.IndexByte(data, delim)
+			if i < 0 {
+				break
+			}
+
+			_, werr := l.Write(data[:i+1])
+			if werr != nil {
+				return n, werr
+			}
+			data = data[i+1:]
+		}
+
+		if len(data) > 0 && (len(data) == len(buf) || err != nil) {
+			_, werr := l.Write(data)
+			if werr != nil {
+				return n, werr
+			}
+			data = nil
+		}
+		pending = copy(buf, data)
+
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		}
 		if err != nil {
-			return err
+			return n, err
+		}
+	}
+}
+
+func (l *logger) writeRecord(b []byte) (int, error) {
+	if l.queue != nil {
+		return l.enqueue(b)
+	}
+
+	s := l.lockShard()
+	defer s.mx.Unlock()
+
+	if l.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+
+	var buf [headerBufferSize]byte
+	err := l.write(s, l.appendHeader(buf[:0]), b)
+	if errors.Is(err, ErrBufferFull) {
+		return 0, err
+	}
+
+	return len(b), err
+}
+
+// lockShard locks the sticky shard, or the next free one if it is busy, which becomes the sticky one. Writers stay on
+// one shard until they contend, so records written without contention keep their order in unordered mode.
+func (l *logger) lockShard() *shard {
+	if len(l.shards) == 1 {
+		l.shards[0].mx.Lock()
+		return l.shards[0]
+	}
+
+	sticky := int(l.stickyShard.Load())
+	for i := 0; i < len(l.shards); i++ {
+		j := (sticky + i) % len(l.shards)
+		if l.shards[j].mx.TryLock() {
+			if i > 0 {
+				l.stickyShard.Store(uint32(j))
+			}
+			return l.shards[j]
 		}
 	}
 
-	copy(l.buffer[l.length:], b)
-	l.length += bLen
+	s := l.shards[sticky]
+	s.mx.Lock()
+	return s
+}
+
+func (l *logger) lockShards() {
+	for _, s := range l.shards {
+		s.mx.Lock()
+	}
+}
+
+func (l *logger) unlockShards() {
+	for _, s := range l.shards {
+		s.mx.Unlock()
+	}
+}
+
+func (l *logger) nextSeq() uint64 {
+	if !l.orderedShards {
+		return 0
+	}
+	return l.seq.Add(1)
+}
+
+// appendHeader appends the timestamp and the prefix configured to be prepended to every record to dst. With framing,
+// they are preceded by space reserved for the frame header, which is filled once the record is buffered.
+func (l *logger) appendHeader(dst []byte) []byte {
+	if l.framed {
+		dst = append(dst, make([]byte, frameHeaderSize)...)
+	}
+
+	if l.timestampLayout != "" {
+		dst = time.Now().AppendFormat(dst, l.timestampLayout)
+		dst = append(dst, ' ')
+	}
+
+	return append(dst, l.prefix...)
+}
+
+// write appends header followed by b to the locked shard s. The shard lock may be released and reacquired while the
+// buffer is dumped.
+func (l *logger) write(s *shard, header, b []byte) error {
+	bLen := len(header) + len(b)
+
+	if l.repeatFormat != "" {
+		if s.active.repeated(b) {
+			s.active.repeats++
+			return nil
+		}
+		l.appendRepeats(s.active)
+	}
+
+	for l.overflowPolicy != OverflowGrowUnbounded && s.free() < bLen {
+		switch l.overflowPolicy {
+		case OverflowDropNewest:
+			l.observeDropped(1, bLen)
+			return ErrBufferFull
+		case OverflowDropOldest:
+			if bLen > s.capacity {
+				l.observeDropped(1, bLen)
+				return ErrBufferFull
+			}
+
+			records, bytes := s.active.dropOldest(bLen - s.free())
+			if records == 0 {
+				l.observeDropped(1, bLen)
+				return ErrBufferFull
+			}
+			l.observeDropped(records, bytes)
+			l.releaseBudget(bytes)
+		default:
+			oversized := bLen > s.capacity && !l.delimited
+
+			s.mx.Unlock()
+			err := l.dumpRecord(header, b, oversized)
+			s.mx.Lock()
+
+			if err != nil || oversized {
+				return err
+			}
+
+			// Close may have made the final dump while the shard was unlocked. Records of the async worker are
+			// written before it.
+			if l.queue == nil && l.closed.Load() {
+				return ErrLoggerClosed
+			}
+
+			if l.delimited && s.free() < bLen {
+				err = l.reserveBudget(s, bLen)
+				if err != nil {
+					return err
+				}
+				return l.appendRecord(s, header, b)
+			}
+		}
+	}
+
+	err := l.reserveBudget(s, bLen)
+	if err != nil {
+		return err
+	}
+
+	err = l.appendRecord(s, header, b)
+
+	if l.flushThreshold > 0 && len(s.active.buffer) >= l.flushThreshold {
+		l.requestFlush()
+	}
+
+	if l.idleFlush > 0 {
+		l.lastWrite.Store(time.Now().UnixNano())
+	}
+
+	return err
+}
+
+// appendRecord appends header followed by b to the locked shard s. The record is buffered even if it fails to be
+// written into the write-ahead log.
+func (l *logger) appendRecord(s *shard, header, b []byte) error {
+	start, reserved := len(s.active.buffer), len(header)+len(b)
+	if l.delimited {
+		if len(s.active.buffer) != s.active.complete() {
+			header = nil
+		}
+		s.active.appendDelimited(header, b, l.recordDelimiter)
+	} else {
+		seq := l.nextSeq()
+		if l.stampSeq {
+			var buf [headerBufferSize]byte
+			header = l.stampHeader(buf[:0], header, seq)
+		}
+		s.active.append(header, b, seq)
+	}
+
+	if l.framed {
+		putFrameHeader(s.active.buffer[start:])
+	}
+
+	l.observeBuffered(len(header) + len(b))
+	l.releaseBudget(reserved - (len(s.active.buffer) - start))
+
+	if l.wal != nil {
+		return l.wal.append(s.active.buffer[start:])
+	}
 
 	return nil
 }
 
+// stampHeader appends header with seq inserted after the frame header to dst.
+func (l *logger) stampHeader(dst, header []byte, seq uint64) []byte {
+	frame := 0
+	if l.framed {
+		frame = frameHeaderSize
+	}
+
+	dst = append(dst, header[:frame]...)
+	dst = strconv.AppendUint(dst, seq, 10)
+	dst = append(dst, ' ')
+
+	return append(dst, header[frame:]...)
+}
+
+// appendRepeats appends a record reporting suppressed repetitions of the last record of seg, if any.
+func (l *logger) appendRepeats(seg *segment) {
+	if seg.repeats == 0 {
+		return
+	}
+
+	l.appendMarker(seg, l.repeatFormat, seg.repeats)
+	seg.repeats = 0
+}
+
+// appendDrops appends a record reporting records dropped since the previous call to seg, if any.
+func (l *logger) appendDrops(seg *segment) {
+	drops := l.unreportedDrops.Swap(0)
+	if drops == 0 {
+		return
+	}
+
+	l.appendMarker(seg, l.dropFormat, int(drops))
+}
+
+// appendSuppressed appends a record reporting records suppressed by the sampler since the previous call to seg, if any.
+func (l *logger) appendSuppressed(seg *segment) {
+	n := l.sampler.takeSuppressed()
+	if n == 0 {
+		return
+	}
+
+	l.appendMarker(seg, l.sampler.cfg.ReportFormat, n)
+}
+
+func (l *logger) reportsSampled() bool {
+	return l.sampler != nil && !l.delimited
+}
+
+// appendMarker appends a record generated by the logger, formatted with format and n, to seg.
+func (l *logger) appendMarker(seg *segment, format string, n int) {
+	var buf [headerBufferSize]byte
+	marker := buf[:0]
+	if l.framed {
+		marker = append(marker, make([]byte, frameHeaderSize)...)
+	}
+	marker = fmt.Appendf(marker, format, n)
+
+	start := len(seg.buffer)
+	seg.append(nil, marker, l.nextSeq())
+	if l.framed {
+		putFrameHeader(seg.buffer[start:])
+	}
+	l.releaseBudget(start - len(seg.buffer))
+}
+
+// reserveBudget accounts n bytes about to be written into the locked shard s in the budget. Once it is exhausted, the
+// oldest records of s are evicted with OverflowDropOldest, otherwise the record is rejected.
+func (l *logger) reserveBudget(s *shard, n int) error {
+	if l.budget == nil {
+		return nil
+	}
+
+	for !l.budget.reserve(n) {
+		if l.overflowPolicy != OverflowDropOldest {
+			l.observeDropped(1, n)
+			return ErrBufferFull
+		}
+
+		records, bytes := s.active.dropOldest(max(n-(l.budget.Limit()-l.budget.Used()), 1))
+		if records == 0 {
+			l.observeDropped(1, n)
+			return ErrBufferFull
+		}
+		l.observeDropped(records, bytes)
+		l.releaseBudget(bytes)
+	}
+
+	return nil
+}
+
+func (l *logger) releaseBudget(n int) {
+	if l.budget != nil && n != 0 {
+		l.budget.add(-n)
+	}
+}
+
+// dumpRecord dumps the buffer and then, if direct is set, header followed by b bypassing the buffer.
+func (l *logger) dumpRecord(header, b []byte, direct bool) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if direct && l.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	err := l.dump(context.Background())
+	if err != nil || !direct {
+		return err
+	}
+
+	if l.stampSeq {
+		var buf [headerBufferSize]byte
+		header = l.stampHeader(buf[:0], header, l.nextSeq())
+	}
+
+	// The record is copied, since b may alias a string or a shared byte, and the Dumper may modify it. A dump abandoned
+	// on timeout may still use the copy, so it is not pooled then.
+	record := l.getRecord()
+	record.data = append(record.data, header...)
+	record.data = append(record.data, b...)
+	b = record.data
+	if l.dumpTimeout <= 0 {
+		defer l.putRecord(record)
+	}
+
+	if l.framed {
+		putFrameHeader(b)
+	}
+
+	start := time.Now()
+	if l.batchDumper != nil {
+		err = l.sendBatch(context.Background(), [][]byte{b})
+	} else {
+		err = l.send(context.Background(), b)
+	}
+	err = l.dumpError(len(b), err)
+	l.observeDump(start, len(b), err)
+
+	return err
+}
+
+// WriteNow dumps the buffer and then b right away, with no other dump in between, so the record reaches the Dumper
+// before WriteNow returns. Records queued by WithAsync may be dumped after it.
+func (l *logger) WriteNow(b []byte) (int, error) {
+	record, err := l.applyMiddlewares(b)
+	if err != nil {
+		return 0, err
+	}
+
+	if record == nil {
+		return len(b), nil
+	}
+
+	var buf [headerBufferSize]byte
+	err = l.dumpRecord(l.appendHeader(buf[:0]), record, true)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(b), nil
+}
+
 func (l *logger) DumpBuffer() error {
+	return l.DumpBufferContext(context.Background())
+}
+
+func (l *logger) DumpBufferContext(ctx context.Context) error {
 	l.mx.Lock()
 	defer l.mx.Unlock()
 
-	return l.dump()
+	if l.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	err := l.dump(ctx)
+	if l.asyncErr != nil {
+		err = errors.Join(l.takeAsyncErr(), err)
+	}
+
+	return err
+}
+
+// Sync blocks until data written before the call has been dumped. Unlike DumpBuffer, it waits for records queued with
+// WithAsync to be buffered first. The last error occurred in background meanwhile is returned too.
+func (l *logger) Sync() error {
+	if l.queue != nil {
+		err := l.waitQueue()
+		if err != nil {
+			return err
+		}
+	}
+
+	return l.DumpBuffer()
+}
+
+// dump swaps active segments of all shards with the spare ones and dumps them, so writers are blocked only for the
+// duration of the swap. Spare segments that failed to be dumped are retried first on the next call.
+func (l *logger) dump(ctx context.Context) error {
+	return l.drain(func() error {
+		return l.dumpSpares(ctx)
+	})
+}
+
+func (l *logger) drain(flush func() error) error {
+	err := flush()
+	if err != nil {
+		return err
+	}
+
+	// Ordered shards are swapped at once, so a dump never misses a record written before one it contains.
+	swapAtOnce := l.orderedShards && len(l.shards) > 1
+	if swapAtOnce {
+		l.lockShards()
+	}
+
+	var sparesLen int
+	for i, s := range l.shards {
+		seal := l.sealSegment
+		if i == 0 && (l.wal != nil || l.dropFormat != "" || l.reportsSampled()) {
+			seal = l.sealFirstSegment
+		}
+
+		if swapAtOnce {
+			l.spares[i] = s.swapLocked(l.spares[i], l.delimited, seal)
+		} else {
+			l.spares[i] = s.swap(l.spares[i], l.delimited, seal)
+		}
+		sparesLen += len(l.spares[i].buffer)
+	}
+	l.sparesLen.Store(int64(sparesLen))
+
+	if swapAtOnce {
+		l.unlockShards()
+	}
+
+	err = flush()
+	if l.wal != nil && l.sparesLen.Load() == 0 {
+		err = errors.Join(err, l.wal.release())
+	}
+
+	return err
+}
+
+// sealFirstSegment reports records dropped and suppressed since the previous dump and rotates the write-ahead log while the first
+// shard is locked for swap, so sealed files contain nothing but data of segments being swapped. An incomplete record
+// moved to the new segment is rewritten to the new file.
+func (l *logger) sealFirstSegment(seg *segment) {
+	if l.sealSegment != nil {
+		l.sealSegment(seg)
+	}
+
+	if l.dropFormat != "" {
+		l.appendDrops(seg)
+	}
+
+	if l.reportsSampled() {
+		l.appendSuppressed(seg)
+	}
+
+	if l.wal == nil {
+		return
+	}
+
+	var tail []byte
+	if l.delimited {
+		tail = seg.buffer[seg.complete():]
+	}
+
+	l.wal.rotate(tail)
 }
 
-func (l *logger) dump() error {
-	if l.length == 0 {
+func (l *logger) dumpSpares(ctx context.Context) error {
+	if l.sparesLen.Load() == 0 {
 		return nil
 	}
 
-	err := l.dumper.Dump(l.buffer[:l.length])
+	if l.abandonedSparesDumped() {
+		return l.releaseSpares(nil)
+	}
+
+	abandoned := l.abandoned != nil
+
+	start := time.Now()
+	err := l.dumpError(int(l.sparesLen.Load()), l.sendSpares(ctx))
+	l.observeDump(start, int(l.sparesLen.Load()), err)
 
+	if !abandoned && l.abandoned != nil {
+		l.detachSpares()
+		l.sparesAbandoned = true
+	}
+
+	return l.releaseSpares(err)
+}
+
+func (l *logger) sendSpares(ctx context.Context) error {
+	switch {
+	case l.batchDumper != nil:
+		l.batch = segmentRecords(l.batch[:0], l.spares, l.orderedShards)
+		return l.sendBatch(ctx, l.batch)
+	case l.readerFrom != nil:
+		buffers := l.spareBuffers()
+		readerFrom := l.readerFrom
+		return l.withDumpTimeout(ctx, func(ctx context.Context) error {
+			err := ctx.Err()
+			if err == nil {
+				_, err = readerFrom.ReadFrom(&buffers)
+			}
+			return err
+		})
+	case len(l.spares) == 1:
+		return l.send(ctx, l.spares[0].buffer)
+	default:
+		l.payload = mergeSegments(l.payload[:0], l.spares, l.orderedShards)
+		return l.send(ctx, l.payload)
+	}
+}
+
+// dumpError wraps err of the Dumper failed to dump n bytes into DumpError.
+func (l *logger) dumpError(n int, err error) error {
 	if err == nil {
-		l.length = 0
+		return nil
+	}
+
+	return &DumpError{
+		N:      n,
+		Dumper: l.dumperName,
+		Err:    err,
+	}
+}
+
+func (l *logger) send(ctx context.Context, b []byte) error {
+	dumper := l.dumper
+	return l.withDumpTimeout(ctx, func(ctx context.Context) error {
+		return dumper.Dump(ctx, b)
+	})
+}
+
+func (l *logger) sendBatch(ctx context.Context, records [][]byte) error {
+	batchDumper := l.batchDumper
+	return l.withDumpTimeout(ctx, func(ctx context.Context) error {
+		err := ctx.Err()
+		if err != nil {
+			return err
+		}
+		return batchDumper.DumpMany(records)
+	})
+}
+
+// withDumpTimeout calls send, abandoning it with ErrDumpTimeout if it does not return within the dump timeout. An
+// abandoned call may still be reading its data, so the caller must not reuse it. send must refer to nothing but the
+// data and the dumper. While an abandoned call is running, the dumper is not called again and ErrDumpTimeout is
+// returned right away.
+func (l *logger) withDumpTimeout(ctx context.Context, send func(ctx context.Context) error) error {
+	if l.dumpTimeout <= 0 {
+		return send(ctx)
+	}
+
+	if l.abandoned != nil {
+		if !l.abandoned.isDone() {
+			return ErrDumpTimeout
+		}
+		l.abandoned = nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.dumpTimeout)
+
+	p := &pendingDump{
+		done: make(chan struct{}),
+	}
+	go func() {
+		defer cancel()
+		defer close(p.done)
+		p.err = send(ctx)
+	}()
+
+	select {
+	case <-p.done:
+		return p.err
+	case <-ctx.Done():
+		if p.isDone() {
+			return p.err
+		}
+
+		l.abandoned = p
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return ErrDumpTimeout
+		}
+		return ctx.Err()
+	}
+}
+
+// waitAbandoned waits for a dump abandoned by withDumpTimeout to return.
+func (l *logger) waitAbandoned() {
+	if l.abandoned != nil {
+		<-l.abandoned.done
+	}
+}
+
+// abandonedSparesDumped reports whether spare segments have been dumped by an abandoned call that returned since, so
+// they must not be dumped again.
+func (l *logger) abandonedSparesDumped() bool {
+	if l.abandoned == nil || !l.sparesAbandoned || !l.abandoned.isDone() {
+		return false
+	}
+
+	err := l.abandoned.err
+	l.abandoned = nil
+	l.sparesAbandoned = false
+
+	return err == nil
+}
+
+func (p *pendingDump) isDone() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// detachSpares leaves spare segments, and everything referring to them, to an abandoned dump, replacing them with
+// copies.
+func (l *logger) detachSpares() {
+	for i, s := range l.spares {
+		l.spares[i] = s.clone()
+	}
+
+	l.payload = nil
+	l.batch = nil
+	l.buffers = nil
+}
+
+// spareBuffers returns spare segments as net.Buffers, which refer to the segments without copying them.
+func (l *logger) spareBuffers() net.Buffers {
+	l.buffers = l.buffers[:0]
+
+	if l.orderedShards && len(l.spares) > 1 {
+		l.buffers = segmentRecords(l.buffers, l.spares, true)
+	} else {
+		for _, s := range l.spares {
+			if len(s.buffer) > 0 {
+				l.buffers = append(l.buffers, s.buffer)
+			}
+		}
+	}
+
+	return l.buffers
+}
+
+func (l *logger) releaseSpares(err error) error {
+	if err != nil && !l.discardOnError {
+		return err
+	}
+
+	var records int
+	for i, s := range l.spares {
+		records += len(s.records)
+		s.reset()
+		if l.maxRetainedSize > 0 {
+			s.shrink(l.maxRetainedSize, l.shards[i].capacity)
+		}
+	}
+	if err != nil {
+		l.observeDropped(records, int(l.sparesLen.Load()))
+	}
+	l.releaseBudget(int(l.sparesLen.Load()))
+	l.sparesLen.Store(0)
+
+	if l.maxRetainedSize > 0 && cap(l.payload) > l.maxRetainedSize {
+		l.payload = nil
 	}
 
 	return err
 }
 
-func (l *logger) AutoDumpBuffer(interval time.Duration) (<-chan error, context.CancelFunc) {
-	ctx, cancel := context.WithCancel(context.Background())
+// WriteTo drains buffered data into w without copying it.
+func (l *logger) WriteTo(w io.Writer) (int64, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed.Load() {
+		return 0, ErrLoggerClosed
+	}
+
+	var n int64
+	err := l.drain(func() error {
+		if l.sparesLen.Load() == 0 {
+			return nil
+		}
+
+		start := time.Now()
+		buffers := l.spareBuffers()
+		written, err := buffers.WriteTo(w)
+		n += written
+		l.observeDump(start, int(written), err)
+
+		return l.releaseSpares(err)
+	})
+
+	return n, err
+}
+
+func (l *logger) PendingBytes() int {
+	pending := int(l.sparesLen.Load())
+
+	for _, s := range l.shards {
+		s.mx.Lock()
+		pending += len(s.active.buffer)
+		s.mx.Unlock()
+	}
+
+	return pending
+}
+
+// Peek returns a copy of the data not dumped yet, in the order it would be dumped. It waits for a dump in progress.
+func (l *logger) Peek() []byte {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.lockShards()
+	defer l.unlockShards()
+
+	size := int(l.sparesLen.Load())
+	segments := make([]*segment, 0, len(l.spares)+len(l.shards))
+	segments = append(segments, l.spares...)
+	for _, s := range l.shards {
+		size += len(s.active.buffer)
+		segments = append(segments, s.active)
+	}
+
+	return mergeSegments(make([]byte, 0, size), segments, l.orderedShards)
+}
+
+// Len is the same as PendingBytes.
+func (l *logger) Len() int {
+	return l.PendingBytes()
+}
+
+// Cap returns the total capacity of the buffer.
+func (l *logger) Cap() int {
+	var capacity int
+	for _, s := range l.shards {
+		s.mx.Lock()
+		capacity += s.capacity
+		s.mx.Unlock()
+	}
+	return capacity
+}
+
+func (l *logger) AutoDumpBuffer(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(l.ctx)
 	errCh := make(chan error, 1)
 
-	go repeatOpWorker(ctx, interval, errCh, l.DumpBuffer)
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		repeatOpWorker(ctx, newSchedule(interval, opts), errCh, func() error {
+			return l.DumpBufferContext(ctx)
+		})
+	}()
 
 	return errCh, cancel
 }
+
+// SetDumper dumps the buffer and makes the logger use dumper from then on. The previous Dumper is kept if the buffer
+// fails to be dumped, it is not closed either way.
+func (l *logger) SetDumper(dumper Dumper) error {
+	return l.SetContextDumper(NewContextDumperAdapter(dumper))
+}
+
+func (l *logger) SetContextDumper(dumper ContextDumper) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	err := l.dump(context.Background())
+	if err != nil {
+		return err
+	}
+
+	l.setDumper(dumper)
+
+	return nil
+}
+
+func (l *logger) setDumper(dumper ContextDumper) {
+	l.dumper = dumper
+	l.dumperName = fmt.Sprintf("%T", unwrapDumper(dumper))
+	l.batchDumper, _ = unwrapDumper(dumper).(BatchDumper)
+	l.readerFrom, _ = unwrapDumper(dumper).(io.ReaderFrom)
+}
+
+// SetCapacity dumps the buffer and changes its capacity. The capacity is kept if the buffer fails to be dumped.
+func (l *logger) SetCapacity(capacity int) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	err := l.dump(context.Background())
+	if err != nil {
+		return err
+	}
+
+	shardCapacity := max(capacity/len(l.shards), 1)
+
+	l.lockShards()
+	defer l.unlockShards()
+
+	l.capacity = capacity
+	for i, s := range l.shards {
+		s.capacity = shardCapacity
+		if len(s.active.buffer) == 0 {
+			s.active = newSegment(shardCapacity)
+		}
+		l.spares[i] = newSegment(shardCapacity)
+	}
+
+	return nil
+}
+
+func (l *logger) Reopen() error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	if l.closed.Load() {
+		return ErrLoggerClosed
+	}
+
+	reopenable, ok := unwrapDumper(l.dumper).(ReopenableDumper)
+	if !ok {
+		return nil
+	}
+
+	return reopenable.Reopen()
+}
+
+// AutoDumpBufferContext dumps the buffer every interval until ctx is done, then dumps it for the last time and closes
+// the returned channel. The channel holds the latest error only.
+func (l *logger) AutoDumpBufferContext(ctx context.Context, interval time.Duration, opts ...AutoDumpOption) <-chan error {
+	workerCtx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(l.ctx, cancel)
+	errCh := make(chan error, 1)
+
+	l.workers.Add(1)
+	go func() {
+		defer l.workers.Done()
+		defer close(errCh)
+		defer stop()
+		defer cancel()
+
+		repeatOp(workerCtx, newSchedule(interval, opts), errCh, func() error {
+			return l.DumpBufferContext(workerCtx)
+		})
+
+		err := l.DumpBufferContext(context.WithoutCancel(ctx))
+		if err != nil && !errors.Is(err, ErrLoggerClosed) {
+			sendLatest(errCh, err)
+		}
+	}()
+
+	return errCh
+}
+
+func (l *logger) Close() error {
+	if !l.closed.CompareAndSwap(false, true) {
+		return ErrLoggerClosed
+	}
+
+	l.lockShards()
+	l.unlockShards()
+
+	if l.queue != nil {
+		l.closeQueue()
+	}
+
+	l.cancel()
+	l.workers.Wait()
+
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	l.waitAbandoned()
+
+	err := errors.Join(l.takeAsyncErr(), l.dump(context.Background()))
+
+	if l.wal != nil {
+		err = errors.Join(err, l.wal.close())
+	}
+
+	l.releaseBudget(l.PendingBytes())
+
+	l.waitAbandoned()
+
+	closer, ok := unwrapDumper(l.dumper).(io.Closer)
+	if ok {
+		err = errors.Join(err, closer.Close())
+	}
+
+	return err
+}
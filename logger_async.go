@@ -0,0 +1,185 @@
+package alslgr
+
+import (
+	"time"
+)
+
+func (l *logger) enqueue(b []byte) (int, error) {
+	l.queueMx.RLock()
+	defer l.queueMx.RUnlock()
+
+	if l.queueClosed {
+		return 0, ErrLoggerClosed
+	}
+
+	record := l.getRecord()
+	record.data = l.appendHeader(record.data)
+	record.header = len(record.data)
+	record.data = append(record.data, b...)
+
+	if l.overflowPolicy == OverflowDropNewest {
+		select {
+		case l.queue <- record:
+		default:
+			l.observeDropped(1, len(record.data))
+			l.putRecord(record)
+			return 0, ErrBufferFull
+		}
+	} else {
+		l.queue <- record
+	}
+
+	return len(b), nil
+}
+
+func (l *logger) asyncWorker() {
+	defer l.workers.Done()
+
+	for record := range l.queue {
+		if record.barrier != nil {
+			close(record.barrier)
+			continue
+		}
+
+		s := l.lockShard()
+		err := l.write(s, record.data[:record.header], record.data[record.header:])
+		s.mx.Unlock()
+
+		l.putRecord(record)
+
+		if err != nil {
+			l.mx.Lock()
+			l.setAsyncErr(err)
+			l.mx.Unlock()
+		}
+	}
+}
+
+// waitQueue blocks until records enqueued before the call have been written into the buffer.
+func (l *logger) waitQueue() error {
+	l.queueMx.RLock()
+
+	if l.queueClosed {
+		l.queueMx.RUnlock()
+		return ErrLoggerClosed
+	}
+
+	barrier := make(chan struct{})
+	l.queue <- &queuedRecord{barrier: barrier}
+
+	l.queueMx.RUnlock()
+
+	<-barrier
+	return nil
+}
+
+func (l *logger) flushWorker() {
+	defer l.workers.Done()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-l.flushCh:
+			l.backgroundDump()
+		}
+	}
+}
+
+func (l *logger) idleWorker() {
+	defer l.workers.Done()
+
+	timer := time.NewTimer(l.idleFlush)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-timer.C:
+			idle := time.Since(time.Unix(0, l.lastWrite.Load()))
+			if idle < l.idleFlush {
+				timer.Reset(l.idleFlush - idle)
+				continue
+			}
+
+			l.backgroundDump()
+			timer.Reset(l.idleFlush)
+		}
+	}
+}
+
+// samplerWorker reports records suppressed by the sampler every sampling interval, so they are reported even if no
+// dump follows for a while.
+func (l *logger) samplerWorker() {
+	defer l.workers.Done()
+
+	ticker := time.NewTicker(l.sampler.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.ctx.Done():
+			return
+		case <-ticker.C:
+			s := l.lockShard()
+			l.appendSuppressed(s.active)
+			s.mx.Unlock()
+		}
+	}
+}
+
+func (l *logger) backgroundDump() {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+
+	err := l.dump(l.ctx)
+	if err != nil {
+		l.setAsyncErr(err)
+	}
+}
+
+func (l *logger) requestFlush() {
+	select {
+	case l.flushCh <- struct{}{}:
+	default:
+	}
+}
+
+func (l *logger) getRecord() *queuedRecord {
+	record, ok := l.records.Get().(*queuedRecord)
+	if !ok {
+		record = &queuedRecord{}
+	}
+	return record
+}
+
+func (l *logger) putRecord(record *queuedRecord) {
+	if l.maxRetainedSize > 0 && cap(record.data) > l.maxRetainedSize {
+		return
+	}
+
+	record.data = record.data[:0]
+	l.records.Put(record)
+}
+
+func (l *logger) closeQueue() {
+	l.queueMx.Lock()
+	defer l.queueMx.Unlock()
+
+	l.queueClosed = true
+	close(l.queue)
+}
+
+// setAsyncErr keeps err occurred in background to be returned by the next DumpBuffer or Close, replacing an earlier
+// one, so errors do not pile up while nobody dumps manually. Every failed dump is reported by OnDumpError and every
+// dropped record by Stats anyway.
+func (l *logger) setAsyncErr(err error) {
+	l.asyncErr = err
+}
+
+func (l *logger) takeAsyncErr() error {
+	err := l.asyncErr
+	l.asyncErr = nil
+	return err
+}
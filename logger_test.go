@@ -2,13 +2,20 @@ package alslgr
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
+	"log"
+	"log/slog"
+	"net"
+	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -213,8 +220,39 @@ const (
 )
 
 func TestConcurrentWrite(t *testing.T) {
+	testConcurrentWrite(t, "CONCURRENT WRITE")
+}
+
+func TestConcurrentShardedWrite(t *testing.T) {
+	testConcurrentWrite(t, "CONCURRENT SHARDED WRITE", WithShards(0, false))
+}
+
+func TestShardedWriteOrder(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d, WithShards(4, false))
+
+	expectedResult := ""
+	for i := 0; i < 8; i++ {
+		record := fmt.Sprintf("%d\n", i)
+		expectedResult += record
+
+		_, err := l.Write([]byte(record))
+		if err != nil {
+			t.Errorf("TEST \"SHARDED WRITE ORDER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	_ = l.Close()
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"SHARDED WRITE ORDER\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+}
+
+func testConcurrentWrite(t *testing.T, name string, opts ...Option) {
 	d := &TestDumper{}
-	l := NewLogger(1<<12, d)
+	l := NewLogger(1<<12, d, opts...)
 
 	var wg sync.WaitGroup
 	for i := 0; i < Concurrency; i++ {
@@ -224,7 +262,7 @@ func TestConcurrentWrite(t *testing.T) {
 
 			_, err := l.Write([]byte(fmt.Sprintf("%d| GOROUTINE WRITE\n", i)))
 			if err != nil {
-				t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+				t.Errorf("TEST \"%s\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", name, err)
 			}
 		}(&wg, i, l, t)
 	}
@@ -233,7 +271,7 @@ func TestConcurrentWrite(t *testing.T) {
 
 	err := l.DumpBuffer()
 	if err != nil {
-		t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		t.Errorf("TEST \"%s\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", name, err)
 		return
 	}
 
@@ -249,13 +287,13 @@ func TestConcurrentWrite(t *testing.T) {
 			if err == io.EOF {
 				break
 			} else {
-				t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", err)
+				t.Errorf("TEST \"%s\" FAILED: EXPECTED READ ERROR \"nil\" GOT \"%v\"\n", name, err)
 				return
 			}
 		}
 
 		if !validate(s) {
-			t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: GOT INVALID ROW \"%s\"\n", s)
+			t.Errorf("TEST \"%s\" FAILED: GOT INVALID ROW \"%s\"\n", name, s)
 			continue
 		}
 
@@ -264,7 +302,7 @@ func TestConcurrentWrite(t *testing.T) {
 		var number int64
 		number, err = strconv.ParseInt(numStr, 10, 64)
 		if err != nil {
-			t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: EXPECTED NUMBER CONVERTION ERROR \"nil\" GOT \"%v\"\n", err)
+			t.Errorf("TEST \"%s\" FAILED: EXPECTED NUMBER CONVERTION ERROR \"nil\" GOT \"%v\"\n", name, err)
 			continue
 		}
 
@@ -273,7 +311,1981 @@ func TestConcurrentWrite(t *testing.T) {
 
 	for i := range checkArr {
 		if !checkArr[i] {
-			t.Errorf("TEST \"CONCURRENT WRITE\" FAILED: LOST ROW \"%d\"\n", i)
+			t.Errorf("TEST \"%s\" FAILED: LOST ROW \"%d\"\n", name, i)
+		}
+	}
+}
+
+type (
+	BlockingContextDumper struct {
+		release chan struct{}
+		dumped  bytes.Buffer
+	}
+)
+
+func (d *BlockingContextDumper) Dump(ctx context.Context, b []byte) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.release:
+		_, err := d.dumped.Write(b)
+		return err
+	}
+}
+
+func TestDumpBufferContext(t *testing.T) {
+	d := &BlockingContextDumper{release: make(chan struct{})}
+	l := NewContextLogger(1<<3, d)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"DUMP CONTEXT\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), AutoDumpTestDelay)
+	defer cancel()
+
+	err = l.DumpBufferContext(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("TEST \"DUMP CONTEXT\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", context.DeadlineExceeded, err)
+	}
+
+	close(d.release)
+
+	err = l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"DUMP CONTEXT\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := d.dumped.String()
+	if givenResult != "A" {
+		t.Errorf("TEST \"DUMP CONTEXT\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+	}
+}
+
+type (
+	ClosingTestDumper struct {
+		TestDumper
+		closed bool
+	}
+)
+
+func (d *ClosingTestDumper) Close() error {
+	d.closed = true
+	return nil
+}
+
+func TestClose(t *testing.T) {
+	d := &ClosingTestDumper{}
+	l := NewLogger(1<<3, d)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	errCh, _ := l.AutoDumpBuffer(time.Hour)
+
+	err = l.Close()
+	if err != nil {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, ok := <-errCh
+	if ok {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED AUTO DUMP CHANNEL TO BE CLOSED\n")
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "A" {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+	}
+
+	if !d.closed {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED DUMPER TO BE CLOSED\n")
+	}
+
+	_, err = l.Write([]byte("A"))
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("TEST \"CLOSE\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrLoggerClosed, err)
+	}
+}
+
+func TestCloseDuringOverflowDump(t *testing.T) {
+	d := &SlowTestDumper{started: make(chan struct{}), release: make(chan struct{})}
+	l := NewLogger(1<<3, d)
+
+	_, _ = l.Write([]byte("AAAAAAAA"))
+
+	writeErrCh := make(chan error)
+	go func() {
+		_, err := l.Write([]byte("B"))
+		writeErrCh <- err
+	}()
+	<-d.started
+
+	closeErrCh := make(chan error)
+	go func() {
+		closeErrCh <- l.Close()
+	}()
+
+	for !l.(*logger).closed.Load() {
+		time.Sleep(time.Millisecond)
+	}
+
+	go func() {
+		for range d.started {
+		}
+	}()
+	close(d.release)
+
+	err := <-writeErrCh
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("TEST \"CLOSE DURING OVERFLOW DUMP\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrLoggerClosed, err)
+	}
+
+	err = <-closeErrCh
+	if err != nil {
+		t.Errorf("TEST \"CLOSE DURING OVERFLOW DUMP\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+	close(d.started)
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "AAAAAAAA" {
+		t.Errorf("TEST \"CLOSE DURING OVERFLOW DUMP\" FAILED: EXPECTED DATA %s GOT %s\n", "AAAAAAAA", givenResult)
+	}
+}
+
+func TestOverflowPolicy(t *testing.T) {
+	tests := []struct {
+		Name                       string
+		Policy                     OverflowPolicy
+		ExpectedWriteErrs          []error
+		DumpedDataBeforeManualDump string
+		DumpedDataAfterManualDump  string
+	}{
+		{
+			Name:                       "BLOCK",
+			Policy:                     OverflowBlock,
+			ExpectedWriteErrs:          []error{nil, nil, nil},
+			DumpedDataBeforeManualDump: "AB",
+			DumpedDataAfterManualDump:  "ABC",
+		},
+		{
+			Name:                       "DROP NEWEST",
+			Policy:                     OverflowDropNewest,
+			ExpectedWriteErrs:          []error{nil, nil, ErrBufferFull},
+			DumpedDataBeforeManualDump: "",
+			DumpedDataAfterManualDump:  "AB",
+		},
+		{
+			Name:                       "DROP OLDEST",
+			Policy:                     OverflowDropOldest,
+			ExpectedWriteErrs:          []error{nil, nil, nil},
+			DumpedDataBeforeManualDump: "",
+			DumpedDataAfterManualDump:  "BC",
+		},
+		{
+			Name:                       "GROW UNBOUNDED",
+			Policy:                     OverflowGrowUnbounded,
+			ExpectedWriteErrs:          []error{nil, nil, nil},
+			DumpedDataBeforeManualDump: "",
+			DumpedDataAfterManualDump:  "ABC",
+		},
+	}
+
+	for _, test := range tests {
+		d := &TestDumper{}
+		l := NewLogger(2, d, WithOverflowPolicy(test.Policy))
+
+		for i, data := range []string{"A", "B", "C"} {
+			_, err := l.Write([]byte(data))
+			if !errors.Is(err, test.ExpectedWriteErrs[i]) {
+				t.Errorf("TEST \"OVERFLOW %s\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", test.Name, test.ExpectedWriteErrs[i], err)
+			}
+		}
+
+		givenResult := string((*bytes.Buffer)(d).Bytes())
+		if givenResult != test.DumpedDataBeforeManualDump {
+			t.Errorf("TEST \"OVERFLOW %s\" FAILED: EXPECTED DATA BEFORE MANUAL DUMP \"%s\" GOT \"%s\"\n",
+				test.Name, test.DumpedDataBeforeManualDump, givenResult)
+		}
+
+		err := l.DumpBuffer()
+		if err != nil {
+			t.Errorf("TEST \"OVERFLOW %s\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		givenResult = string((*bytes.Buffer)(d).Bytes())
+		if givenResult != test.DumpedDataAfterManualDump {
+			t.Errorf("TEST \"OVERFLOW %s\" FAILED: EXPECTED DATA AFTER MANUAL DUMP \"%s\" GOT \"%s\"\n",
+				test.Name, test.DumpedDataAfterManualDump, givenResult)
+		}
+	}
+}
+
+func TestAsyncWrite(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(2, d, WithAsync(1<<4))
+
+	for _, data := range []string{"A", "B", "C"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"ASYNC WRITE\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"ASYNC WRITE\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "ABC" {
+		t.Errorf("TEST \"ASYNC WRITE\" FAILED: EXPECTED DATA %s GOT %s\n", "ABC", givenResult)
+	}
+
+	_, err = l.Write([]byte("A"))
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("TEST \"ASYNC WRITE\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrLoggerClosed, err)
+	}
+}
+
+func TestOrderedShards(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d, WithShards(4, true))
+
+	expectedResult := ""
+	for i := 0; i < 10; i++ {
+		data := strconv.Itoa(i)
+		expectedResult += data
+
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"ORDERED SHARDS\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"ORDERED SHARDS\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"ORDERED SHARDS\" FAILED: EXPECTED DATA %s GOT %s\n", expectedResult, givenResult)
+	}
+}
+
+type (
+	SlowTestDumper struct {
+		TestDumper
+		started chan struct{}
+		release chan struct{}
+	}
+)
+
+func (d *SlowTestDumper) Dump(b []byte) error {
+	d.started <- struct{}{}
+	<-d.release
+	return d.TestDumper.Dump(b)
+}
+
+func TestWriteDuringDump(t *testing.T) {
+	d := &SlowTestDumper{started: make(chan struct{}), release: make(chan struct{})}
+	l := NewLogger(1<<3, d)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	dumpErrCh := make(chan error)
+	go func() {
+		dumpErrCh <- l.DumpBuffer()
+	}()
+	<-d.started
+
+	writeErrCh := make(chan error)
+	go func() {
+		_, err := l.Write([]byte("B"))
+		writeErrCh <- err
+	}()
+
+	select {
+	case err = <-writeErrCh:
+		if err != nil {
+			t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	case <-time.After(AutoDumpTestDelay):
+		t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: WRITE BLOCKED BY DUMP\n")
+	}
+
+	close(d.release)
+
+	err = <-dumpErrCh
+	if err != nil {
+		t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	go func() {
+		<-d.started
+	}()
+
+	err = l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "AB" {
+		t.Errorf("TEST \"WRITE DURING DUMP\" FAILED: EXPECTED DATA %s GOT %s\n", "AB", givenResult)
+	}
+}
+
+func TestRecordDelimiter(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(4, d, WithRecordDelimiter('\n'))
+
+	steps := []struct {
+		Data           string
+		ExpectedDumped string
+	}{
+		{Data: "AB", ExpectedDumped: ""},
+		{Data: "C\nDE", ExpectedDumped: ""},
+		{Data: "FGH", ExpectedDumped: "ABC\n"},
+		{Data: "\nI", ExpectedDumped: "ABC\n"},
+	}
+
+	for _, step := range steps {
+		_, err := l.Write([]byte(step.Data))
+		if err != nil {
+			t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+
+		givenResult := string((*bytes.Buffer)(d).Bytes())
+		if givenResult != step.ExpectedDumped {
+			t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED DATA %q GOT %q\n", step.ExpectedDumped, givenResult)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "ABC\nDEFGH\n" {
+		t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED DATA %q GOT %q\n", "ABC\nDEFGH\n", givenResult)
+	}
+
+	_, err = l.Write([]byte("\n"))
+	if err != nil {
+		t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult = string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "ABC\nDEFGH\nI\n" {
+		t.Errorf("TEST \"RECORD DELIMITER\" FAILED: EXPECTED DATA %q GOT %q\n", "ABC\nDEFGH\nI\n", givenResult)
+	}
+}
+
+func TestRetainOnError(t *testing.T) {
+	for _, retain := range []bool{true, false} {
+		d := &TestDumper{}
+		l := NewLogger(1<<4, d, WithRetainOnError(retain))
+
+		_, err := l.Write([]byte(ForcedErrorMessage))
+		if err != nil {
+			t.Errorf("TEST \"RETAIN ON ERROR %t\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", retain, err)
+		}
+
+		err = l.DumpBuffer()
+		if !errors.Is(err, forcedError) {
+			t.Errorf("TEST \"RETAIN ON ERROR %t\" FAILED: EXPECTED DUMP ERROR \"%v\" GOT \"%v\"\n", retain, forcedError, err)
+		}
+
+		_, err = l.Write([]byte("A"))
+		if err != nil {
+			t.Errorf("TEST \"RETAIN ON ERROR %t\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", retain, err)
+		}
+
+		expectedPending := 1
+		if retain {
+			expectedPending += len(ForcedErrorMessage)
 		}
+
+		pending := l.PendingBytes()
+		if pending != expectedPending {
+			t.Errorf("TEST \"RETAIN ON ERROR %t\" FAILED: EXPECTED PENDING BYTES %d GOT %d\n", retain, expectedPending, pending)
+		}
+	}
+}
+
+func TestSlogHandler(t *testing.T) {
+	d := &TestDumper{}
+	h := NewSlogHandler(NewLogger(1<<10, d), SlogFormatJSON, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey && len(groups) == 0 {
+				return slog.Attr{}
+			}
+			return a
+		},
+	})
+
+	slog.New(h).With("k", "v").Info("A")
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "" {
+		t.Errorf("TEST \"SLOG HANDLER\" FAILED: EXPECTED DATA BEFORE FLUSH %s GOT %s\n", "", givenResult)
+	}
+
+	err := h.Flush()
+	if err != nil {
+		t.Errorf("TEST \"SLOG HANDLER\" FAILED: EXPECTED FLUSH ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedResult := `{"level":"INFO","msg":"A","k":"v"}` + "\n"
+	givenResult = string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"SLOG HANDLER\" FAILED: EXPECTED DATA %s GOT %s\n", expectedResult, givenResult)
+	}
+
+	err = h.Close()
+	if err != nil {
+		t.Errorf("TEST \"SLOG HANDLER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+}
+
+func TestWriteSyncer(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<3, d)
+	ws := NewWriteSyncer(l)
+
+	_, err := ws.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"WRITE SYNCER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = ws.Sync()
+	if err != nil {
+		t.Errorf("TEST \"WRITE SYNCER\" FAILED: EXPECTED SYNC ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "A" {
+		t.Errorf("TEST \"WRITE SYNCER\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+	}
+
+	_ = l.Close()
+
+	err = ws.Sync()
+	if err != nil {
+		t.Errorf("TEST \"WRITE SYNCER\" FAILED: EXPECTED SYNC AFTER CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+}
+
+type (
+	BatchTestDumper struct {
+		TestDumper
+		batches []string
+	}
+)
+
+func (d *BatchTestDumper) DumpMany(records [][]byte) error {
+	d.batches = append(d.batches, fmt.Sprintf("%q", records))
+	return nil
+}
+
+func TestBatchDumper(t *testing.T) {
+	d := &BatchTestDumper{}
+	l := NewLogger(4, d)
+
+	for _, data := range []string{"A", "BC", "DE", "FGHIJ"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"BATCH DUMPER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	expected := `[["A" "BC"] ["DE"] ["FGHIJ"]]`
+	if fmt.Sprint(d.batches) != expected {
+		t.Errorf("TEST \"BATCH DUMPER\" FAILED: EXPECTED BATCHES %s GOT %v\n", expected, d.batches)
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "" {
+		t.Errorf("TEST \"BATCH DUMPER\" FAILED: EXPECTED DUMPED DATA %s GOT %s\n", "", givenResult)
+	}
+}
+
+type (
+	ReaderFromTestDumper struct {
+		TestDumper
+		reads int
+	}
+)
+
+func (d *ReaderFromTestDumper) ReadFrom(r io.Reader) (int64, error) {
+	d.reads++
+	return (*bytes.Buffer)(&d.TestDumper).ReadFrom(r)
+}
+
+func TestZeroCopyDump(t *testing.T) {
+	d := &ReaderFromTestDumper{}
+	l := NewLogger(1<<4, d, WithShards(4, true))
+
+	for _, data := range []string{"A", "B", "C"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "ABC" || d.reads != 1 {
+		t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED DATA %s READ ONCE GOT %s READ %d TIMES\n", "ABC", givenResult, d.reads)
+	}
+
+	_, err = l.Write([]byte("D"))
+	if err != nil {
+		t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	var buf bytes.Buffer
+	n, err := l.WriteTo(&buf)
+	if err != nil || n != 1 || buf.String() != "D" {
+		t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED WRITE TO %s GOT %s (%d BYTES, ERROR \"%v\")\n", "D", buf.String(), n, err)
+	}
+
+	if l.PendingBytes() != 0 {
+		t.Errorf("TEST \"ZERO COPY DUMP\" FAILED: EXPECTED PENDING BYTES %d GOT %d\n", 0, l.PendingBytes())
+	}
+}
+
+func TestMaxRetainedSize(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<2, d, WithOverflowPolicy(OverflowGrowUnbounded), WithMaxRetainedSize(1<<3))
+
+	_, err := l.Write(bytes.Repeat([]byte("A"), 1<<4))
+	if err != nil {
+		t.Errorf("TEST \"MAX RETAINED SIZE\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		err = l.DumpBuffer()
+		if err != nil {
+			t.Errorf("TEST \"MAX RETAINED SIZE\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	for _, seg := range append([]*segment{l.(*logger).shards[0].active}, l.(*logger).spares...) {
+		if cap(seg.buffer) > 1<<3 {
+			t.Errorf("TEST \"MAX RETAINED SIZE\" FAILED: EXPECTED RETAINED BUFFER AT MOST %d GOT %d\n", 1<<3, cap(seg.buffer))
+		}
+	}
+}
+
+func TestFlushThreshold(t *testing.T) {
+	d := &SlowTestDumper{started: make(chan struct{}), release: make(chan struct{})}
+	l := NewLogger(1<<3, d, WithFlushThreshold(2))
+
+	for _, data := range []string{"A", "B"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"FLUSH THRESHOLD\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	select {
+	case <-d.started:
+	case <-time.After(AutoDumpTestDelay):
+		t.Errorf("TEST \"FLUSH THRESHOLD\" FAILED: EXPECTED BACKGROUND DUMP\n")
+	}
+	close(d.release)
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"FLUSH THRESHOLD\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "AB" {
+		t.Errorf("TEST \"FLUSH THRESHOLD\" FAILED: EXPECTED DATA %s GOT %s\n", "AB", givenResult)
+	}
+}
+
+func TestIdleFlush(t *testing.T) {
+	d := &SlowTestDumper{started: make(chan struct{}), release: make(chan struct{})}
+	l := NewLogger(1<<3, d, WithIdleFlush(AutoDumpTestDelay))
+
+	start := time.Now()
+	for _, data := range []string{"A", "B", "C"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"IDLE FLUSH\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+		time.Sleep(AutoDumpTestDelay / 2)
+	}
+
+	select {
+	case <-d.started:
+		if time.Since(start) < AutoDumpTestDelay*3/2 {
+			t.Errorf("TEST \"IDLE FLUSH\" FAILED: DUMPED BEFORE IDLE\n")
+		}
+	case <-time.After(AutoDumpTestDelay * 2):
+		t.Errorf("TEST \"IDLE FLUSH\" FAILED: EXPECTED BACKGROUND DUMP\n")
+	}
+	close(d.release)
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"IDLE FLUSH\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "ABC" {
+		t.Errorf("TEST \"IDLE FLUSH\" FAILED: EXPECTED DATA %s GOT %s\n", "ABC", givenResult)
+	}
+}
+
+func TestBackgroundErrors(t *testing.T) {
+	var failures atomic.Int32
+	d := DumperFunc(func(_ []byte) error {
+		failures.Add(1)
+		return errors.New(ForcedErrorMessage)
+	})
+
+	l := NewLogger(1<<3, d, WithIdleFlush(AutoDumpTestDelay/10))
+
+	_, _ = l.Write([]byte("A"))
+
+	for failures.Load() < 5 {
+		time.Sleep(AutoDumpTestDelay / 10)
+	}
+
+	err := l.DumpBuffer()
+
+	var joined interface{ Unwrap() []error }
+	if errors.As(err, &joined) && len(joined.Unwrap()) > 2 {
+		t.Errorf("TEST \"BACKGROUND ERRORS\" FAILED: EXPECTED AT MOST %d ERRORS GOT %d\n", 2, len(joined.Unwrap()))
+	}
+
+	if err == nil || !strings.Contains(err.Error(), ForcedErrorMessage) {
+		t.Errorf("TEST \"BACKGROUND ERRORS\" FAILED: EXPECTED DUMP ERROR %q GOT \"%v\"\n", ForcedErrorMessage, err)
+	}
+
+	_ = l.Close()
+}
+
+func TestAutoDumpContext(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<3, d)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := l.AutoDumpBufferContext(ctx, time.Hour)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"AUTO DUMP CONTEXT\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	cancel()
+
+	for err = range errCh {
+		t.Errorf("TEST \"AUTO DUMP CONTEXT\" FAILED: EXPECTED ASYNC DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "A" {
+		t.Errorf("TEST \"AUTO DUMP CONTEXT\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+	}
+}
+
+func TestAutoDumpOptions(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<3, d)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"AUTO DUMP OPTIONS\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	errCh, cancel := l.AutoDumpBuffer(time.Hour, WithAutoDumpImmediate(), WithAutoDumpJitter(0.5))
+	defer cancel()
+
+	select {
+	case err = <-errCh:
+		if err != nil {
+			t.Errorf("TEST \"AUTO DUMP OPTIONS\" FAILED: EXPECTED ASYNC DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	case <-time.After(AutoDumpTestDelay):
+		t.Errorf("TEST \"AUTO DUMP OPTIONS\" FAILED: EXPECTED IMMEDIATE DUMP\n")
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "A" {
+		t.Errorf("TEST \"AUTO DUMP OPTIONS\" FAILED: EXPECTED DATA %s GOT %s\n", "A", givenResult)
+	}
+
+	s := newSchedule(time.Second, []AutoDumpOption{WithAutoDumpJitter(0.5)})
+	for i := 0; i < 100; i++ {
+		next := s.next()
+		if next < time.Second/2 || next > time.Second*3/2 {
+			t.Errorf("TEST \"AUTO DUMP OPTIONS\" FAILED: EXPECTED JITTERED INTERVAL WITHIN %v GOT %v\n", time.Second/2, next)
+		}
+	}
+}
+
+type (
+	TestMetricsRecorder struct {
+		Buffered int
+		Dumped   int
+		Errors   int
+		Dropped  int
+	}
+)
+
+func (r *TestMetricsRecorder) RecordBuffered(bytes int) {
+	r.Buffered += bytes
+}
+
+func (r *TestMetricsRecorder) RecordDump(bytes int, _ time.Duration, err error) {
+	if err != nil {
+		r.Errors++
+		return
+	}
+	r.Dumped += bytes
+}
+
+func (r *TestMetricsRecorder) RecordDropped(_, bytes int) {
+	r.Dropped += bytes
+}
+
+func TestStats(t *testing.T) {
+	d := &TestDumper{}
+	r := &TestMetricsRecorder{}
+	l := NewLogger(4, d, WithOverflowPolicy(OverflowDropNewest), WithMetricsRecorder(r))
+
+	for _, data := range []string{"AB", "CD", "E"} {
+		_, _ = l.Write([]byte(data))
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"STATS\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, _ = l.Write([]byte("F"))
+
+	expectedStats := Stats{
+		BufferedRecords: 3,
+		BufferedBytes:   5,
+		Dumps:           1,
+		DumpedBytes:     4,
+		DroppedRecords:  1,
+		DroppedBytes:    1,
+		PendingBytes:    1,
+	}
+
+	stats := l.Stats()
+	if stats.DumpDuration != stats.LastDumpDuration {
+		t.Errorf("TEST \"STATS\" FAILED: EXPECTED EQUAL DUMP DURATIONS GOT %v AND %v\n",
+			stats.DumpDuration, stats.LastDumpDuration)
+	}
+
+	stats.DumpDuration, stats.LastDumpDuration = 0, 0
+	if stats != expectedStats {
+		t.Errorf("TEST \"STATS\" FAILED: EXPECTED %+v GOT %+v\n", expectedStats, stats)
+	}
+
+	expectedRecorder := TestMetricsRecorder{Buffered: 5, Dumped: 4, Dropped: 1}
+	if *r != expectedRecorder {
+		t.Errorf("TEST \"STATS\" FAILED: EXPECTED RECORDER %+v GOT %+v\n", expectedRecorder, *r)
+	}
+}
+
+func TestDumpCallbacks(t *testing.T) {
+	var flushed, failed int
+	var dumpErr error
+
+	d := &TestDumper{}
+	l := NewLogger(1<<5, d,
+		WithOnFlush(func(bytes int) {
+			flushed += bytes
+		}),
+		WithOnDumpError(func(err error, bytes int) {
+			dumpErr = err
+			failed += bytes
+		}),
+	)
+
+	for _, data := range []string{"AB", ForcedErrorMessage} {
+		_, _ = l.Write([]byte(data))
+		_ = l.DumpBuffer()
+	}
+
+	if flushed != 2 {
+		t.Errorf("TEST \"DUMP CALLBACKS\" FAILED: EXPECTED FLUSHED BYTES %d GOT %d\n", 2, flushed)
+	}
+
+	if failed != len(ForcedErrorMessage) || !errors.Is(dumpErr, forcedError) {
+		t.Errorf("TEST \"DUMP CALLBACKS\" FAILED: EXPECTED FAILED BYTES %d WITH ERROR \"%v\" GOT %d WITH ERROR \"%v\"\n",
+			len(ForcedErrorMessage), forcedError, failed, dumpErr)
+	}
+}
+
+func TestLeveledLogger(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLeveledLogger(NewLogger(1<<10, d), SlogFormatJSON, slog.LevelInfo)
+
+	l.Debug("A")
+	l.With("k", "v").Info("B", "n", 1)
+	l.Warnf("C%d", 2)
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"LEVELED LOGGER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedResult := `{"level":"INFO","msg":"B","k":"v","n":1}` + "\n" + `{"level":"WARN","msg":"C2"}` + "\n"
+	givenResult := regexp.MustCompile(`"time":"[^"]*",`).ReplaceAllString(string((*bytes.Buffer)(d).Bytes()), "")
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"LEVELED LOGGER\" FAILED: EXPECTED DATA %s GOT %s\n", expectedResult, givenResult)
+	}
+}
+
+func TestRecordHeader(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Opts     []Option
+		Data     []string
+		Expected string
+	}{
+		{
+			Name:     "SYNC",
+			Opts:     []Option{WithTimestamp(time.DateOnly), WithPrefix("app: ")},
+			Data:     []string{"A\n", "B\n"},
+			Expected: `^(\d{4}-\d{2}-\d{2} app: [AB]\n){2}$`,
+		},
+		{
+			Name:     "ASYNC",
+			Opts:     []Option{WithAsync(1), WithPrefix("app: ")},
+			Data:     []string{"A\n", "B\n"},
+			Expected: `^app: A\napp: B\n$`,
+		},
+		{
+			Name:     "DELIMITED",
+			Opts:     []Option{WithRecordDelimiter('\n'), WithPrefix("app: ")},
+			Data:     []string{"A", "B\n", "C\n"},
+			Expected: `^app: AB\napp: C\n$`,
+		},
+	}
+
+	for _, test := range tests {
+		d := &TestDumper{}
+		l := NewLogger(1<<10, d, test.Opts...)
+
+		for _, data := range test.Data {
+			_, err := l.Write([]byte(data))
+			if err != nil {
+				t.Errorf("TEST \"RECORD HEADER %s\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+			}
+		}
+
+		err := l.Close()
+		if err != nil {
+			t.Errorf("TEST \"RECORD HEADER %s\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		givenResult := string((*bytes.Buffer)(d).Bytes())
+		if !regexp.MustCompile(test.Expected).MatchString(givenResult) {
+			t.Errorf("TEST \"RECORD HEADER %s\" FAILED: EXPECTED DATA MATCHING %q GOT %q\n", test.Name, test.Expected, givenResult)
+		}
+	}
+}
+
+func TestMiddlewares(t *testing.T) {
+	upper := func(b []byte) ([]byte, error) {
+		return bytes.ToUpper(b), nil
+	}
+	skip := func(b []byte) ([]byte, error) {
+		if bytes.HasPrefix(b, []byte("SKIP")) {
+			return nil, nil
+		}
+		if bytes.HasPrefix(b, []byte(ForcedErrorMessage)) {
+			return nil, forcedError
+		}
+		return b, nil
+	}
+
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d, WithMiddlewares(upper, skip), WithPrefix("> "))
+
+	steps := []struct {
+		Data          string
+		ExpectedN     int
+		ExpectedError error
+	}{
+		{Data: "a", ExpectedN: 1},
+		{Data: "skip", ExpectedN: 4},
+		{Data: strings.ToLower(ForcedErrorMessage), ExpectedN: 0, ExpectedError: forcedError},
+		{Data: "b", ExpectedN: 1},
+	}
+
+	for _, step := range steps {
+		n, err := l.Write([]byte(step.Data))
+		if n != step.ExpectedN || !errors.Is(err, step.ExpectedError) {
+			t.Errorf("TEST \"MIDDLEWARES\" FAILED: EXPECTED WRITE %d \"%v\" GOT %d \"%v\"\n", step.ExpectedN, step.ExpectedError, n, err)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"MIDDLEWARES\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedResult := "> A> B"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"MIDDLEWARES\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+}
+
+func TestRedactor(t *testing.T) {
+	redact := NewRedactor(RedactorConfig{
+		Keys:     []string{"password", "token"},
+		Patterns: []*regexp.Regexp{CreditCardPattern},
+	})
+
+	tests := []struct {
+		Data     string
+		Expected string
+	}{
+		{Data: "user=a password=secret ok", Expected: "user=a password=*** ok"},
+		{Data: `{"Token":"se \"cr\" et","n":1}`, Expected: `{"Token":"***","n":1}`},
+		{Data: "token: abc, card 4111 1111 1111 1111.", Expected: "token: ***, card ***."},
+		{Data: "nothing to hide", Expected: "nothing to hide"},
+		{Data: "ts=1672574400123 ns=1672574400123456789", Expected: "ts=1672574400123 ns=1672574400123456789"},
+	}
+
+	for _, test := range tests {
+		givenResult, err := redact([]byte(test.Data))
+		if err != nil || string(givenResult) != test.Expected {
+			t.Errorf("TEST \"REDACTOR\" FAILED: EXPECTED %q \"nil\" GOT %q \"%v\"\n", test.Expected, givenResult, err)
+		}
+	}
+}
+
+func TestSampler(t *testing.T) {
+	now := time.Now()
+
+	s := newSampler(SamplerConfig{Rate: 3, Every: 2})
+	s.now = func() time.Time {
+		return now
+	}
+
+	steps := []struct {
+		Data       string
+		Elapsed    time.Duration
+		Expected   string
+		Suppressed int
+	}{
+		{Data: "A", Expected: "A"},
+		{Data: "A", Suppressed: 1},
+		{Data: "B", Expected: "B"},
+		{Data: "A", Expected: "A"},
+		{Data: "C"},
+		{Data: "C", Elapsed: time.Second, Expected: "C", Suppressed: 1},
+	}
+
+	for i, step := range steps {
+		now = now.Add(step.Elapsed)
+
+		givenResult, err := s.sample([]byte(step.Data))
+		if err != nil || string(givenResult) != step.Expected {
+			t.Errorf("TEST \"SAMPLER %d\" FAILED: EXPECTED %q \"nil\" GOT %q \"%v\"\n", i, step.Expected, givenResult, err)
+		}
+
+		if step.Suppressed > 0 {
+			suppressed := s.takeSuppressed()
+			if suppressed != step.Suppressed {
+				t.Errorf("TEST \"SAMPLER %d\" FAILED: EXPECTED %d SUPPRESSED GOT %d\n", i, step.Suppressed, suppressed)
+			}
+		}
+	}
+}
+
+func TestSamplerReports(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Interval time.Duration
+		Expected string
+	}{
+		{Name: "ON DUMP", Interval: time.Hour, Expected: "A\nx2\n"},
+		{Name: "ON INTERVAL", Interval: AutoDumpTestDelay / 10, Expected: "A\nx1\nC\nx1\n"},
+	}
+
+	for _, test := range tests {
+		d := &TestDumper{}
+		l := NewLogger(1<<10, d, WithSampler(SamplerConfig{Rate: 1, Interval: test.Interval, ReportFormat: "x%d\n"}))
+
+		_, _ = l.Write([]byte("A\n"))
+		_, _ = l.Write([]byte("B\n"))
+
+		if test.Interval < AutoDumpTestDelay {
+			time.Sleep(AutoDumpTestDelay)
+			_, _ = l.Write([]byte("C\n"))
+			_, _ = l.Write([]byte("D\n"))
+		} else {
+			_, _ = l.Write([]byte("C\n"))
+		}
+
+		err := l.Close()
+		if err != nil {
+			t.Errorf("TEST \"SAMPLER REPORTS %s\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+		}
+
+		givenResult := string((*bytes.Buffer)(d).Bytes())
+		if givenResult != test.Expected {
+			t.Errorf("TEST \"SAMPLER REPORTS %s\" FAILED: EXPECTED DATA %q GOT %q\n", test.Name, test.Expected, givenResult)
+		}
+	}
+}
+
+func TestRepeatSuppression(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d, WithRepeatSuppression("x%d\n"), WithPrefix("> "))
+
+	for _, data := range []string{"A\n", "A\n", "A\n", "B\n", "B\n"} {
+		_, err := l.Write([]byte(data))
+		if err != nil {
+			t.Errorf("TEST \"REPEAT SUPPRESSION\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"REPEAT SUPPRESSION\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, _ = l.Write([]byte("B\n"))
+	_ = l.Close()
+
+	expectedResult := "> A\nx2\n> B\nx1\n> B\n"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"REPEAT SUPPRESSION\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+}
+
+func TestWriteNow(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d)
+
+	_, _ = l.Write([]byte("A"))
+
+	n, err := l.WriteNow([]byte("B"))
+	if n != 1 || err != nil {
+		t.Errorf("TEST \"WRITE NOW\" FAILED: EXPECTED WRITE %d \"nil\" GOT %d \"%v\"\n", 1, n, err)
+	}
+
+	expectedResult := "AB"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"WRITE NOW\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+
+	_ = l.Close()
+
+	_, err = l.WriteNow([]byte("C"))
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("TEST \"WRITE NOW\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrLoggerClosed, err)
+	}
+}
+
+func TestPeek(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<4, d, WithShards(2, true))
+
+	for _, data := range []string{"A", "C", "B"} {
+		_, _ = l.Write([]byte(data))
+	}
+
+	expectedResult := "ACB"
+	givenResult := string(l.Peek())
+	if givenResult != expectedResult || l.Len() != len(expectedResult) || l.Cap() != 1<<4 {
+		t.Errorf("TEST \"PEEK\" FAILED: EXPECTED %q %d %d GOT %q %d %d\n",
+			expectedResult, len(expectedResult), 1<<4, givenResult, l.Len(), l.Cap())
+	}
+
+	givenResult = string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "" {
+		t.Errorf("TEST \"PEEK\" FAILED: EXPECTED DUMPED DATA %q GOT %q\n", "", givenResult)
+	}
+}
+
+func readWriteAheadLog(t *testing.T, dir string) string {
+	names, err := filepath.Glob(filepath.Join(dir, "*"+walFileExt))
+	if err != nil {
+		t.Errorf("FAILED TO LIST WRITE-AHEAD LOG \"%s\": %v\n", dir, err)
+	}
+
+	var data string
+	for _, name := range names {
+		data += readFile(t, name)
+	}
+	return data
+}
+
+func TestWriteAheadLog(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "wal")
+
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d, WithWriteAheadLog(dir, true))
+
+	steps := []struct {
+		Data        string
+		Dump        bool
+		ExpectedWAL string
+	}{
+		{Data: "A", ExpectedWAL: "A"},
+		{Data: "B", ExpectedWAL: "AB"},
+		{Dump: true, ExpectedWAL: ""},
+		{Data: ForcedErrorMessage, ExpectedWAL: ForcedErrorMessage},
+		{Dump: true, ExpectedWAL: ForcedErrorMessage},
+		{Data: "C", ExpectedWAL: ForcedErrorMessage + "C"},
+	}
+
+	for i, step := range steps {
+		if step.Dump {
+			_ = l.DumpBuffer()
+		} else {
+			_, err := l.Write([]byte(step.Data))
+			if err != nil {
+				t.Errorf("TEST \"WRITE-AHEAD LOG %d\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", i, err)
+			}
+		}
+
+		givenResult := readWriteAheadLog(t, dir)
+		if givenResult != step.ExpectedWAL {
+			t.Errorf("TEST \"WRITE-AHEAD LOG %d\" FAILED: EXPECTED %q GOT %q\n", i, step.ExpectedWAL, givenResult)
+		}
+	}
+
+	_ = l.Close()
+
+	givenResult := readWriteAheadLog(t, dir)
+	if givenResult != ForcedErrorMessage+"C" {
+		t.Errorf("TEST \"WRITE-AHEAD LOG\" FAILED: EXPECTED AFTER CLOSE %q GOT %q\n", ForcedErrorMessage+"C", givenResult)
+	}
+}
+
+func TestRecover(t *testing.T) {
+	dir := t.TempDir()
+
+	l := NewLogger(1<<10, &TestDumper{}, WithWriteAheadLog(dir, false))
+	for _, data := range []string{"A", "B", "C"} {
+		_, _ = l.Write([]byte(data))
+		if data == "A" {
+			_ = l.DumpBuffer()
+		}
+	}
+
+	d := &TestDumper{}
+
+	err := Recover(dir, d)
+	if err != nil {
+		t.Errorf("TEST \"RECOVER\" FAILED: EXPECTED ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedResult := "BC"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"RECOVER\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+
+	givenResult = readWriteAheadLog(t, dir)
+	if givenResult != "" {
+		t.Errorf("TEST \"RECOVER\" FAILED: EXPECTED DATA LEFT %q GOT %q\n", "", givenResult)
+	}
+}
+
+func TestFraming(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d, WithFraming(), WithPrefix("> "))
+
+	for _, data := range []string{"A", "BC"} {
+		_, _ = l.Write([]byte(data))
+	}
+	_ = l.Close()
+
+	dumped := (*bytes.Buffer)(d).Bytes()
+
+	tests := []struct {
+		Name            string
+		Data            []byte
+		Opts            []FrameReaderOption
+		ExpectedRecords []string
+		ExpectedErr     error
+	}{
+		{
+			Name:            "VALID",
+			Data:            dumped,
+			ExpectedRecords: []string{"> A", "> BC"},
+			ExpectedErr:     io.EOF,
+		},
+		{
+			Name:            "TRUNCATED",
+			Data:            dumped[:len(dumped)-1],
+			ExpectedRecords: []string{"> A"},
+			ExpectedErr:     io.ErrUnexpectedEOF,
+		},
+		{
+			Name:            "CORRUPT",
+			Data:            append(bytes.Clone(dumped[:len(dumped)-1]), 'D'),
+			ExpectedRecords: []string{"> A"},
+			ExpectedErr:     ErrCorruptFrame,
+		},
+		{
+			Name:            "OVERSIZED",
+			Data:            []byte{0x7f, 0xff, 0xff, 0xff, 0, 0, 0, 0, 'A'},
+			ExpectedRecords: nil,
+			ExpectedErr:     ErrCorruptFrame,
+		},
+		{
+			Name:            "TRUNCATED LONG",
+			Data:            []byte{0x01, 0x00, 0x00, 0x00, 0, 0, 0, 0, 'A'},
+			Opts:            []FrameReaderOption{WithMaxFrameSize(1 << 30)},
+			ExpectedRecords: nil,
+			ExpectedErr:     io.ErrUnexpectedEOF,
+		},
+		{
+			Name:            "MAX FRAME SIZE",
+			Data:            dumped,
+			Opts:            []FrameReaderOption{WithMaxFrameSize(3)},
+			ExpectedRecords: []string{"> A"},
+			ExpectedErr:     ErrCorruptFrame,
+		},
+	}
+
+	for _, test := range tests {
+		r := NewFrameReader(bytes.NewReader(test.Data), test.Opts...)
+
+		var records []string
+		var err error
+		for {
+			var record []byte
+			record, err = r.Next()
+			if err != nil {
+				break
+			}
+			records = append(records, string(record))
+		}
+
+		if fmt.Sprint(records) != fmt.Sprint(test.ExpectedRecords) || !errors.Is(err, test.ExpectedErr) {
+			t.Errorf("TEST \"FRAMING %s\" FAILED: EXPECTED %q \"%v\" GOT %q \"%v\"\n",
+				test.Name, test.ExpectedRecords, test.ExpectedErr, records, err)
+		}
+	}
+}
+
+func TestDumpTimeout(t *testing.T) {
+	release := make(chan struct{})
+
+	var running, overlapped, calls atomic.Int32
+	var dumped []string
+	d := DumperFunc(func(b []byte) error {
+		calls.Add(1)
+		if running.Add(1) > 1 {
+			overlapped.Store(1)
+		}
+		defer running.Add(-1)
+
+		<-release
+		dumped = append(dumped, string(b))
+		return nil
+	})
+
+	l := NewLogger(1<<10, d, WithDumpTimeout(10*time.Millisecond))
+
+	_, _ = l.Write([]byte("A"))
+
+	err := l.DumpBuffer()
+	if !errors.Is(err, ErrDumpTimeout) || l.PendingBytes() != 1 {
+		t.Errorf("TEST \"DUMP TIMEOUT\" FAILED: EXPECTED DUMP ERROR \"%v\" WITH %d PENDING BYTES GOT \"%v\" WITH %d\n",
+			ErrDumpTimeout, 1, err, l.PendingBytes())
+	}
+
+	_, _ = l.Write([]byte("B"))
+
+	err = l.DumpBuffer()
+	if !errors.Is(err, ErrDumpTimeout) || calls.Load() != 1 {
+		t.Errorf("TEST \"DUMP TIMEOUT\" FAILED: EXPECTED DUMP ERROR \"%v\" WITH %d CALLS GOT \"%v\" WITH %d\n",
+			ErrDumpTimeout, 1, err, calls.Load())
+	}
+
+	close(release)
+
+	err = l.Close()
+	if err != nil {
+		t.Errorf("TEST \"DUMP TIMEOUT\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	if running.Load() != 0 || overlapped.Load() != 0 {
+		t.Errorf("TEST \"DUMP TIMEOUT\" FAILED: EXPECTED NO CONCURRENT DUMPS GOT %d RUNNING OVERLAPPED %d\n",
+			running.Load(), overlapped.Load())
+	}
+
+	if !slices.Contains(dumped, "A") || !slices.Contains(dumped, "B") || len(dumped) != 2 {
+		t.Errorf("TEST \"DUMP TIMEOUT\" FAILED: EXPECTED DATA \"A\" AND \"B\" GOT %q\n", dumped)
+	}
+}
+
+func TestDumpError(t *testing.T) {
+	l := NewLogger(1<<10, &TestDumper{})
+
+	_, _ = l.Write([]byte(ForcedErrorMessage))
+
+	err := l.DumpBuffer()
+
+	var dumpErr *DumpError
+	if !errors.As(err, &dumpErr) || !errors.Is(err, forcedError) ||
+		dumpErr.N != len(ForcedErrorMessage) || dumpErr.Dumper != "*alslgr.TestDumper" {
+		t.Errorf("TEST \"DUMP ERROR\" FAILED: EXPECTED DUMP ERROR OF %d BYTES TO %s GOT \"%v\"\n",
+			len(ForcedErrorMessage), "*alslgr.TestDumper", err)
+	}
+}
+
+func TestHealthy(t *testing.T) {
+	l := NewLogger(1<<10, &TestDumper{}, WithUnhealthyThreshold(2))
+
+	steps := []struct {
+		Data            string
+		ExpectedHealthy bool
+		ExpectedErr     error
+	}{
+		{Data: "A", ExpectedHealthy: true},
+		{Data: ForcedErrorMessage, ExpectedHealthy: true, ExpectedErr: forcedError},
+		{Data: "", ExpectedHealthy: false, ExpectedErr: forcedError},
+	}
+
+	for i, step := range steps {
+		_, _ = l.Write([]byte(step.Data))
+		_ = l.DumpBuffer()
+
+		err, at := l.LastDumpError()
+		if l.Healthy() != step.ExpectedHealthy || !errors.Is(err, step.ExpectedErr) || (err != nil) == at.IsZero() {
+			t.Errorf("TEST \"HEALTHY %d\" FAILED: EXPECTED %t \"%v\" GOT %t \"%v\" AT %v\n",
+				i, step.ExpectedHealthy, step.ExpectedErr, l.Healthy(), err, at)
+		}
+	}
+}
+
+func TestSetDumperAndCapacity(t *testing.T) {
+	first, second := &TestDumper{}, &TestDumper{}
+	l := NewLogger(1<<10, first)
+
+	_, _ = l.Write([]byte("A"))
+
+	err := l.SetDumper(second)
+	if err != nil {
+		t.Errorf("TEST \"SET DUMPER\" FAILED: EXPECTED ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = l.SetCapacity(2)
+	if err != nil || l.Cap() != 2 {
+		t.Errorf("TEST \"SET CAPACITY\" FAILED: EXPECTED CAPACITY %d WITH ERROR \"nil\" GOT %d \"%v\"\n", 2, l.Cap(), err)
+	}
+
+	for _, data := range []string{"B", "C", "D"} {
+		_, _ = l.Write([]byte(data))
+	}
+
+	for _, test := range []struct {
+		Dumper   *TestDumper
+		Expected string
+	}{
+		{Dumper: first, Expected: "A"},
+		{Dumper: second, Expected: "BC"},
+	} {
+		givenResult := string((*bytes.Buffer)(test.Dumper).Bytes())
+		if givenResult != test.Expected {
+			t.Errorf("TEST \"SET DUMPER\" FAILED: EXPECTED DATA %q GOT %q\n", test.Expected, givenResult)
+		}
+	}
+}
+
+func TestManager(t *testing.T) {
+	access, audit := &TestDumper{}, &TestDumper{}
+
+	m := NewManager()
+	for name, d := range map[string]Dumper{"access": access, "audit": audit} {
+		err := m.Register(name, NewLogger(1<<10, d))
+		if err != nil {
+			t.Errorf("TEST \"MANAGER\" FAILED: EXPECTED REGISTER ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	err := m.Register("access", NewLogger(1<<10, access))
+	if !errors.Is(err, ErrLoggerExists) {
+		t.Errorf("TEST \"MANAGER\" FAILED: EXPECTED REGISTER ERROR \"%v\" GOT \"%v\"\n", ErrLoggerExists, err)
+	}
+
+	_, _ = m.Logger("access").Write([]byte("A"))
+	_, _ = m.Logger("audit").Write([]byte(ForcedErrorMessage))
+
+	err = m.DumpAll()
+	if !errors.Is(err, forcedError) || !strings.HasPrefix(err.Error(), "logger audit: ") {
+		t.Errorf("TEST \"MANAGER\" FAILED: EXPECTED DUMP ERROR OF \"audit\" GOT \"%v\"\n", err)
+	}
+
+	errCh, cancel := m.AutoDumpAll(time.Hour)
+	defer cancel()
+
+	_ = m.CloseAll()
+
+	_, ok := <-errCh
+	if ok {
+		t.Errorf("TEST \"MANAGER\" FAILED: EXPECTED AUTO DUMP CHANNEL TO BE CLOSED\n")
+	}
+
+	givenResult := string((*bytes.Buffer)(access).Bytes())
+	if givenResult != "A" {
+		t.Errorf("TEST \"MANAGER\" FAILED: EXPECTED DATA %q GOT %q\n", "A", givenResult)
+	}
+}
+
+func TestBuffer(t *testing.T) {
+	type event struct {
+		ID   int
+		Name string
+	}
+
+	d := &TestDumper{}
+	b := NewBuffer[event](2, NewSerializingDumper(func(dst []byte, e event) ([]byte, error) {
+		return fmt.Appendf(dst, "%d:%s;", e.ID, e.Name), nil
+	}, d))
+
+	for i, name := range []string{"A", "B", "C"} {
+		err := b.Write(event{ID: i, Name: name})
+		if err != nil {
+			t.Errorf("TEST \"BUFFER\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "0:A;1:B;" || b.Len() != 1 {
+		t.Errorf("TEST \"BUFFER\" FAILED: EXPECTED DATA %q WITH %d BUFFERED GOT %q WITH %d\n", "0:A;1:B;", 1, givenResult, b.Len())
+	}
+
+	err := b.Close()
+	if err != nil {
+		t.Errorf("TEST \"BUFFER\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult = string((*bytes.Buffer)(d).Bytes())
+	if givenResult != "0:A;1:B;2:C;" {
+		t.Errorf("TEST \"BUFFER\" FAILED: EXPECTED DATA %q GOT %q\n", "0:A;1:B;2:C;", givenResult)
+	}
+}
+
+func TestSequenceNumbers(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d, WithShards(4, false), WithSequenceNumbers())
+
+	const writers, writes = 4, 100
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < writes; j++ {
+				_, _ = l.Write([]byte("x\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	err := l.Close()
+	if err != nil {
+		t.Errorf("TEST \"SEQUENCE NUMBERS\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string((*bytes.Buffer)(d).Bytes()), "\n"), "\n")
+	if len(lines) != writers*writes {
+		t.Fatalf("TEST \"SEQUENCE NUMBERS\" FAILED: EXPECTED %d RECORDS GOT %d\n", writers*writes, len(lines))
+	}
+
+	for i, line := range lines {
+		expectedLine := fmt.Sprintf("%d x", i+1)
+		if line != expectedLine {
+			t.Fatalf("TEST \"SEQUENCE NUMBERS\" FAILED: EXPECTED RECORD %q GOT %q\n", expectedLine, line)
+		}
+	}
+}
+
+func TestDropMarker(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(4, d, WithOverflowPolicy(OverflowDropNewest), WithDropMarker("[%d dropped]"))
+
+	for _, record := range []string{"AB", "CD", "EF", "GH"} {
+		_, _ = l.Write([]byte(record))
+	}
+
+	err := l.DumpBuffer()
+	if err != nil {
+		t.Errorf("TEST \"DROP MARKER\" FAILED: EXPECTED DUMP ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_, _ = l.Write([]byte("IJ"))
+	_ = l.DumpBuffer()
+
+	expectedResult := "ABCD[2 dropped]IJ"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"DROP MARKER\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+
+	stats := l.Stats()
+	if stats.DroppedRecords != 2 || stats.DroppedBytes != 4 {
+		t.Errorf("TEST \"DROP MARKER\" FAILED: EXPECTED %d RECORDS AND %d BYTES DROPPED GOT %d AND %d\n",
+			2, 4, stats.DroppedRecords, stats.DroppedBytes)
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d)
+
+	stdLogger := NewStdLogger(l, "http: ", 0)
+	stdLogger.Print("A")
+
+	restore := RedirectStdLog(l)
+	log.Print("B")
+
+	err := restore()
+	if err != nil {
+		t.Errorf("TEST \"STD LOGGER\" FAILED: EXPECTED RESTORE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expectedResult := "http: A\n"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if !strings.HasPrefix(givenResult, expectedResult) || !strings.HasSuffix(givenResult, " B\n") {
+		t.Errorf("TEST \"STD LOGGER\" FAILED: EXPECTED DATA %q FOLLOWED BY \"B\" GOT %q\n", expectedResult, givenResult)
+	}
+
+	_ = l.Close()
+
+	var fallback bytes.Buffer
+	w := newStdWriter(l, &fallback)
+
+	_, err = w.Write([]byte("C"))
+	if err != nil || fallback.String() != "C" {
+		t.Errorf("TEST \"STD LOGGER\" FAILED: EXPECTED FALLBACK DATA %q GOT %q WITH ERROR \"%v\"\n", "C", fallback.String(), err)
+	}
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(8)
+	d1, d2 := &TestDumper{}, &TestDumper{}
+	l1 := NewLogger(1<<4, d1, WithBudget(b), WithOverflowPolicy(OverflowDropNewest))
+	l2 := NewLogger(1<<4, d2, WithBudget(b), WithOverflowPolicy(OverflowDropOldest))
+
+	_, _ = l1.Write([]byte("ABCD"))
+	_, _ = l2.Write([]byte("EF"))
+	_, _ = l2.Write([]byte("GH"))
+
+	_, err := l1.Write([]byte("I"))
+	if !errors.Is(err, ErrBufferFull) {
+		t.Errorf("TEST \"BUDGET\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrBufferFull, err)
+	}
+
+	_, err = l2.Write([]byte("IJ"))
+	if err != nil {
+		t.Errorf("TEST \"BUDGET\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	if b.Used() != 8 {
+		t.Errorf("TEST \"BUDGET\" FAILED: EXPECTED %d BYTES USED GOT %d\n", 8, b.Used())
+	}
+
+	_ = l1.Close()
+	_ = l2.Close()
+
+	givenResult := string((*bytes.Buffer)(d1).Bytes()) + string((*bytes.Buffer)(d2).Bytes())
+	if givenResult != "ABCDGHIJ" || b.Used() != 0 {
+		t.Errorf("TEST \"BUDGET\" FAILED: EXPECTED DATA %q WITH %d BYTES USED GOT %q WITH %d\n", "ABCDGHIJ", 0, givenResult, b.Used())
+	}
+}
+
+func TestSchedule(t *testing.T) {
+	friday := time.Date(2024, time.March, 1, 17, 50, 30, 0, time.UTC)
+
+	tests := []struct {
+		Name     string
+		Expr     string
+		From     time.Time
+		Expected time.Time
+	}{
+		{
+			Name:     "HALF HOURS",
+			Expr:     "0,30 * * * *",
+			From:     friday,
+			Expected: time.Date(2024, time.March, 1, 18, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "WORKING HOURS",
+			Expr:     "*/15 9-17 * * 1-5",
+			From:     friday,
+			Expected: time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "DAY OF MONTH OR WEEK",
+			Expr:     "0 0 29 * 0",
+			From:     friday,
+			Expected: time.Date(2024, time.March, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Name:     "LEAP DAY",
+			Expr:     "0 0 29 2 *",
+			From:     friday,
+			Expected: time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		s, err := NewCronSchedule(test.Expr)
+		if err != nil {
+			t.Errorf("TEST \"SCHEDULE %s\" FAILED: EXPECTED PARSE ERROR \"nil\" GOT \"%v\"\n", test.Name, err)
+			continue
+		}
+
+		next := s.Next(test.From)
+		if !next.Equal(test.Expected) {
+			t.Errorf("TEST \"SCHEDULE %s\" FAILED: EXPECTED NEXT %v GOT %v\n", test.Name, test.Expected, next)
+		}
+	}
+
+	for _, expr := range []string{"* * * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *"} {
+		_, err := NewCronSchedule(expr)
+		if !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("TEST \"SCHEDULE\" FAILED: EXPECTED PARSE ERROR OF %q \"%v\" GOT \"%v\"\n", expr, ErrInvalidSchedule, err)
+		}
+	}
+
+	s, err := NewClockSchedule("12:30", "00:00")
+	if err != nil {
+		t.Fatalf("TEST \"SCHEDULE CLOCK\" FAILED: EXPECTED PARSE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	expected := time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC)
+	next := s.Next(friday)
+	if !next.Equal(expected) {
+		t.Errorf("TEST \"SCHEDULE CLOCK\" FAILED: EXPECTED NEXT %v GOT %v\n", expected, next)
+	}
+
+	sched := newSchedule(time.Second, []AutoDumpOption{WithAutoDumpJitter(0.5), WithAutoDumpSchedule(s)})
+	now := time.Now()
+	interval, gap := sched.next(), s.Next(now).Sub(now)
+	if interval < gap/2-time.Second || interval > gap*3/2 {
+		t.Errorf("TEST \"SCHEDULE CLOCK\" FAILED: EXPECTED JITTERED INTERVAL AROUND %v GOT %v\n", gap, interval)
+	}
+}
+
+func TestMaxRecordSize(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d, WithMaxRecordSize(4, ""))
+
+	_, err := l.Write([]byte("ABCDE"))
+	if !errors.Is(err, ErrRecordTooLarge) {
+		t.Errorf("TEST \"MAX RECORD SIZE\" FAILED: EXPECTED WRITE ERROR \"%v\" GOT \"%v\"\n", ErrRecordTooLarge, err)
+	}
+
+	if l.Stats().DroppedRecords != 1 {
+		t.Errorf("TEST \"MAX RECORD SIZE\" FAILED: EXPECTED %d DROPPED RECORDS GOT %d\n", 1, l.Stats().DroppedRecords)
+	}
+
+	l = NewLogger(1<<6, d, WithMaxRecordSize(8, "~\n"))
+
+	for _, record := range []string{"ABCD\n", "EFGHIJKLMN\n"} {
+		n, err := l.Write([]byte(record))
+		if err != nil || n != len(record) {
+			t.Errorf("TEST \"MAX RECORD SIZE\" FAILED: EXPECTED WRITE OF %d BYTES GOT %d WITH ERROR \"%v\"\n", len(record), n, err)
+		}
+	}
+	_ = l.Close()
+
+	expectedResult := "ABCD\nEFGHIJ~\n"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"MAX RECORD SIZE\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+}
+
+func TestWriteStringByteReadFrom(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d)
+
+	_, _ = fmt.Fprintf(l, "%s\n", "A")
+	_, _ = l.WriteString("B\n")
+	_ = l.WriteByte('C')
+
+	n, err := l.ReadFrom(strings.NewReader("D\nE\nF"))
+	if err != nil || n != 5 {
+		t.Errorf("TEST \"WRITE STRING BYTE READ FROM\" FAILED: EXPECTED COPY OF %d BYTES GOT %d WITH ERROR \"%v\"\n", 5, n, err)
+	}
+
+	_ = l.Close()
+
+	expectedResult := "A\nB\nCD\nE\nF"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult || l.Stats().BufferedRecords != 6 {
+		t.Errorf("TEST \"WRITE STRING BYTE READ FROM\" FAILED: EXPECTED DATA %q IN %d RECORDS GOT %q IN %d\n",
+			expectedResult, 6, givenResult, l.Stats().BufferedRecords)
+	}
+}
+
+type (
+	// UpperTestDumper modifies dumped data in place.
+	UpperTestDumper struct {
+		TestDumper
+	}
+)
+
+func (d *UpperTestDumper) Dump(b []byte) error {
+	for i, c := range b {
+		if c >= 'a' && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+	return d.TestDumper.Dump(b)
+}
+
+func TestWriteStringDirect(t *testing.T) {
+	d := &UpperTestDumper{}
+	l := NewLogger(4, d)
+
+	s := "hello world\n"
+	_, err := l.WriteString(s)
+	if err != nil {
+		t.Errorf("TEST \"WRITE STRING DIRECT\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	err = l.WriteByte('x')
+	if err != nil {
+		t.Errorf("TEST \"WRITE STRING DIRECT\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	_ = l.Close()
+
+	expectedResult := "HELLO WORLD\nX"
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != expectedResult || s != "hello world\n" || byteRecords['x'] != 'x' {
+		t.Errorf("TEST \"WRITE STRING DIRECT\" FAILED: EXPECTED DATA %q AND UNMODIFIED RECORDS GOT %q\n",
+			expectedResult, givenResult)
+	}
+}
+
+func TestWriteV(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<6, d, WithShards(4, true), WithAsync(16))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 16; j++ {
+				_, _ = l.WriteV([]byte("["), []byte("AB"), []byte("]\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	n, err := l.WriteV(net.Buffers{[]byte("C"), []byte("D\n")}...)
+	if err != nil || n != 3 {
+		t.Errorf("TEST \"WRITE V\" FAILED: EXPECTED WRITE OF %d BYTES GOT %d WITH ERROR \"%v\"\n", 3, n, err)
+	}
+
+	_ = l.Close()
+
+	expectedResult := strings.Repeat("[AB]\n", 64) + "CD\n"
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != expectedResult {
+		t.Errorf("TEST \"WRITE V\" FAILED: EXPECTED DATA %q GOT %q\n", expectedResult, givenResult)
+	}
+}
+
+func TestSync(t *testing.T) {
+	d := &TestDumper{}
+	l := NewLogger(1<<10, d, WithAsync(1<<10), WithFlushThreshold(1<<4))
+
+	for i := 0; i < 100; i++ {
+		_, _ = l.Write([]byte("A"))
+	}
+
+	err := l.Sync()
+	if err != nil {
+		t.Errorf("TEST \"SYNC\" FAILED: EXPECTED SYNC ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	givenResult := string((*bytes.Buffer)(d).Bytes())
+	if givenResult != strings.Repeat("A", 100) {
+		t.Errorf("TEST \"SYNC\" FAILED: EXPECTED DATA %q GOT %q\n", strings.Repeat("A", 100), givenResult)
+	}
+
+	_, _ = l.Write([]byte(ForcedErrorMessage))
+
+	err = l.Sync()
+	if !errors.Is(err, forcedError) {
+		t.Errorf("TEST \"SYNC\" FAILED: EXPECTED SYNC ERROR \"%v\" GOT \"%v\"\n", forcedError, err)
+	}
+
+	_ = l.Close()
+
+	err = l.Sync()
+	if !errors.Is(err, ErrLoggerClosed) {
+		t.Errorf("TEST \"SYNC\" FAILED: EXPECTED SYNC ERROR \"%v\" GOT \"%v\"\n", ErrLoggerClosed, err)
+	}
+}
+
+var (
+	writeBenchmarks = []struct {
+		Name string
+		Opts []Option
+	}{
+		{Name: "PLAIN"},
+		{Name: "SHARDED", Opts: []Option{WithShards(4, false)}},
+		{Name: "ORDERED SHARDS", Opts: []Option{WithShards(4, true)}},
+		{Name: "TIMESTAMP AND PREFIX", Opts: []Option{WithTimestamp(time.RFC3339), WithPrefix("host ")}},
+		{Name: "FRAMED", Opts: []Option{WithFraming()}},
+		{Name: "SEQUENCE NUMBERS", Opts: []Option{WithSequenceNumbers()}},
+		{Name: "DELIMITED", Opts: []Option{WithRecordDelimiter('\n')}},
+	}
+)
+
+func discardDumper(_ []byte) error {
+	return nil
+}
+
+func TestWriteAllocations(t *testing.T) {
+	record := []byte("small record\n")
+
+	for _, test := range writeBenchmarks {
+		l := NewLogger(1<<10, DumperFunc(discardDumper), test.Opts...)
+
+		// Segments reach their final size once the buffer has been dumped.
+		for i := 0; i < 1<<8; i++ {
+			_, _ = l.Write(record)
+		}
+
+		allocs := testing.AllocsPerRun(1<<10, func() {
+			_, _ = l.Write(record)
+			_, _ = l.WriteString("small record\n")
+			_ = l.WriteByte('\n')
+		})
+		if allocs != 0 {
+			t.Errorf("TEST \"WRITE ALLOCATIONS %s\" FAILED: EXPECTED %d ALLOCATIONS GOT %v\n", test.Name, 0, allocs)
+		}
+
+		_ = l.Close()
+	}
+}
+
+func BenchmarkWrite(b *testing.B) {
+	record := []byte("small record\n")
+
+	for _, bench := range writeBenchmarks {
+		b.Run(bench.Name, func(b *testing.B) {
+			l := NewLogger(1<<16, DumperFunc(discardDumper), bench.Opts...)
+			defer func() {
+				_ = l.Close()
+			}()
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(record)))
+
+			for i := 0; i < b.N; i++ {
+				_, _ = l.Write(record)
+			}
+		})
+	}
+}
+
+func BenchmarkWriteParallel(b *testing.B) {
+	record := []byte("small record\n")
+
+	for _, bench := range writeBenchmarks {
+		b.Run(bench.Name, func(b *testing.B) {
+			l := NewLogger(1<<16, DumperFunc(discardDumper), bench.Opts...)
+			defer func() {
+				_ = l.Close()
+			}()
+
+			b.ReportAllocs()
+			b.SetBytes(int64(len(record)))
+
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					_, _ = l.Write(record)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkWriteAsync(b *testing.B) {
+	record := []byte("small record\n")
+
+	l := NewLogger(1<<16, DumperFunc(discardDumper), WithAsync(1<<10))
+	defer func() {
+		_ = l.Close()
+	}()
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(record)))
+
+	for i := 0; i < b.N; i++ {
+		_, _ = l.Write(record)
+	}
+}
+
+func BenchmarkWriteString(b *testing.B) {
+	l := NewLogger(1<<16, DumperFunc(discardDumper))
+	defer func() {
+		_ = l.Close()
+	}()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = l.WriteString("small record\n")
+	}
+}
+
+func BenchmarkWriteV(b *testing.B) {
+	fragments := [][]byte{[]byte("host "), []byte("small record"), []byte("\n")}
+
+	l := NewLogger(1<<16, DumperFunc(discardDumper))
+	defer func() {
+		_ = l.Close()
+	}()
+
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = l.WriteV(fragments...)
 	}
 }
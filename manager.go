@@ -0,0 +1,129 @@
+package alslgr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+type (
+	manager struct {
+		mx sync.RWMutex
+
+		names   []string
+		loggers map[string]Logger
+		closed  bool
+
+		ctx     context.Context
+		cancel  context.CancelFunc
+		workers sync.WaitGroup
+	}
+)
+
+// NewManager creates a Manager owning loggers registered by name, so they can be dumped and closed together.
+func NewManager() Manager {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &manager{
+		loggers: make(map[string]Logger),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (m *manager) Register(name string, logger Logger) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if m.closed {
+		return ErrLoggerClosed
+	}
+
+	_, ok := m.loggers[name]
+	if ok {
+		return ErrLoggerExists
+	}
+
+	m.names = append(m.names, name)
+	m.loggers[name] = logger
+
+	return nil
+}
+
+// Logger returns the logger registered by name or nil.
+func (m *manager) Logger(name string) Logger {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	return m.loggers[name]
+}
+
+func (m *manager) DumpAll() error {
+	return m.DumpAllContext(context.Background())
+}
+
+// DumpAllContext dumps all loggers concurrently. Errors are prefixed with names of loggers.
+func (m *manager) DumpAllContext(ctx context.Context) error {
+	return m.forEach(func(logger Logger) error {
+		return logger.DumpBufferContext(ctx)
+	})
+}
+
+// AutoDumpAll dumps all loggers every interval by a single goroutine, until the returned function is called or the
+// manager is closed. The channel holds the latest error only.
+func (m *manager) AutoDumpAll(interval time.Duration, opts ...AutoDumpOption) (<-chan error, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(m.ctx)
+	errCh := make(chan error, 1)
+
+	m.workers.Add(1)
+	go func() {
+		defer m.workers.Done()
+		repeatOpWorker(ctx, newSchedule(interval, opts), errCh, func() error {
+			return m.DumpAllContext(ctx)
+		})
+	}()
+
+	return errCh, cancel
+}
+
+// CloseAll stops auto dumps and closes all loggers.
+func (m *manager) CloseAll() error {
+	m.mx.Lock()
+	if m.closed {
+		m.mx.Unlock()
+		return ErrLoggerClosed
+	}
+	m.closed = true
+	m.mx.Unlock()
+
+	m.cancel()
+	m.workers.Wait()
+
+	return m.forEach(Logger.Close)
+}
+
+func (m *manager) forEach(op func(logger Logger) error) error {
+	m.mx.RLock()
+	names := append([]string(nil), m.names...)
+	m.mx.RUnlock()
+
+	errs := make([]error, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			err := op(m.Logger(name))
+			if err != nil {
+				errs[i] = fmt.Errorf("logger %s: %w", name, err)
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+package alslgr
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// Stats is a snapshot of logger counters accumulated since it has been created.
+	Stats struct {
+		BufferedRecords uint64
+		BufferedBytes   uint64
+
+		Dumps       uint64
+		DumpErrors  uint64
+		DumpedBytes uint64
+
+		DroppedRecords uint64
+		DroppedBytes   uint64
+
+		DumpDuration     time.Duration
+		LastDumpDuration time.Duration
+
+		PendingBytes int
+	}
+
+	stats struct {
+		bufferedRecords atomic.Uint64
+		bufferedBytes   atomic.Uint64
+
+		dumps       atomic.Uint64
+		dumpErrors  atomic.Uint64
+		dumpedBytes atomic.Uint64
+
+		droppedRecords atomic.Uint64
+		droppedBytes   atomic.Uint64
+
+		dumpDuration     atomic.Int64
+		lastDumpDuration atomic.Int64
+
+		failedDumps  atomic.Int64
+		lastDumpFail atomic.Pointer[dumpFailure]
+	}
+
+	dumpFailure struct {
+		err error
+		at  time.Time
+	}
+)
+
+func (l *logger) Stats() Stats {
+	return Stats{
+		BufferedRecords:  l.stats.bufferedRecords.Load(),
+		BufferedBytes:    l.stats.bufferedBytes.Load(),
+		Dumps:            l.stats.dumps.Load(),
+		DumpErrors:       l.stats.dumpErrors.Load(),
+		DumpedBytes:      l.stats.dumpedBytes.Load(),
+		DroppedRecords:   l.stats.droppedRecords.Load(),
+		DroppedBytes:     l.stats.droppedBytes.Load(),
+		DumpDuration:     time.Duration(l.stats.dumpDuration.Load()),
+		LastDumpDuration: time.Duration(l.stats.lastDumpDuration.Load()),
+		PendingBytes:     l.PendingBytes(),
+	}
+}
+
+// Healthy reports whether fewer consecutive dumps than the threshold set by WithUnhealthyThreshold have failed.
+func (l *logger) Healthy() bool {
+	return l.stats.failedDumps.Load() < int64(l.unhealthyThreshold)
+}
+
+// LastDumpError returns the error of the last failed dump and the time it failed at, or nil if no dump has failed.
+func (l *logger) LastDumpError() (error, time.Time) {
+	failure := l.stats.lastDumpFail.Load()
+	if failure == nil {
+		return nil, time.Time{}
+	}
+	return failure.err, failure.at
+}
+
+func (l *logger) observeBuffered(bytes int) {
+	l.stats.bufferedRecords.Add(1)
+	l.stats.bufferedBytes.Add(uint64(bytes))
+
+	if l.metrics != nil {
+		l.metrics.RecordBuffered(bytes)
+	}
+}
+
+// observeDump accounts a dump of bytes started at start. Bytes are counted as dumped only if err is nil.
+func (l *logger) observeDump(start time.Time, bytes int, err error) {
+	duration := time.Since(start)
+
+	l.stats.dumps.Add(1)
+	l.stats.dumpDuration.Add(int64(duration))
+	l.stats.lastDumpDuration.Store(int64(duration))
+	if err != nil {
+		l.stats.dumpErrors.Add(1)
+		l.stats.failedDumps.Add(1)
+		l.stats.lastDumpFail.Store(&dumpFailure{err: err, at: start.Add(duration)})
+	} else {
+		l.stats.dumpedBytes.Add(uint64(bytes))
+		l.stats.failedDumps.Store(0)
+	}
+
+	if l.metrics != nil {
+		l.metrics.RecordDump(bytes, duration, err)
+	}
+
+	if err != nil && l.onDumpError != nil {
+		l.onDumpError(err, bytes)
+	} else if err == nil && l.onFlush != nil {
+		l.onFlush(bytes)
+	}
+}
+
+func (l *logger) observeDropped(records, bytes int) {
+	l.stats.droppedRecords.Add(uint64(records))
+	l.stats.droppedBytes.Add(uint64(bytes))
+	if l.dropFormat != "" {
+		l.unreportedDrops.Add(int64(records))
+	}
+
+	if l.metrics != nil {
+		l.metrics.RecordDropped(records, bytes)
+	}
+}
@@ -0,0 +1,34 @@
+package alslgr
+
+type (
+	// WriteMiddleware transforms a record before it enters the buffer. It must not modify or retain b, but may return
+	// it as is. A nil result drops the record, an error is returned by Write.
+	WriteMiddleware func(b []byte) ([]byte, error)
+)
+
+func (l *logger) applyMiddlewares(b []byte) ([]byte, error) {
+	var err error
+	for _, mw := range l.middlewares {
+		b, err = mw(b)
+		if err != nil || b == nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// limitRecordSize rejects or truncates records longer than the maximum size.
+func (l *logger) limitRecordSize(b []byte) ([]byte, error) {
+	if len(b) <= l.maxRecordSize {
+		return b, nil
+	}
+
+	if l.truncateMarker == "" {
+		l.observeDropped(1, len(b))
+		return nil, ErrRecordTooLarge
+	}
+
+	record := make([]byte, 0, max(l.maxRecordSize, len(l.truncateMarker)))
+	record = append(record, b[:max(l.maxRecordSize-len(l.truncateMarker), 0)]...)
+	return append(record, l.truncateMarker...), nil
+}
@@ -2,21 +2,106 @@ package alslgr
 
 import (
 	"context"
+	"math/rand"
 	"time"
+	"unicode/utf8"
 )
 
-func repeatOpWorker(ctx context.Context, interval time.Duration, errCh chan<- error, op func() error) {
+type (
+	schedule struct {
+		next      func() time.Duration
+		jitter    float64
+		immediate bool
+	}
+)
+
+func newSchedule(interval time.Duration, opts []AutoDumpOption) schedule {
+	s := schedule{
+		next: func() time.Duration {
+			return interval
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&s)
+	}
+
+	if s.jitter > 0 {
+		next, fraction := s.next, s.jitter
+		s.next = func() time.Duration {
+			return jitter(next(), fraction)
+		}
+	}
+
+	return s
+}
+
+func repeatOpWorker(ctx context.Context, s schedule, errCh chan<- error, op func() error) {
 	defer close(errCh)
 
+	repeatOp(ctx, s, errCh, op)
+}
+
+func repeatOp(ctx context.Context, s schedule, errCh chan<- error, op func() error) {
+	if s.immediate {
+		trySend(errCh, op())
+	}
+
 	for {
+		timer := time.NewTimer(s.next())
+
 		select {
 		case <-ctx.Done():
+			timer.Stop()
 			return
-		case <-time.After(interval):
-			select {
-			case errCh <- op():
-			default:
-			}
+		case <-timer.C:
+			trySend(errCh, op())
 		}
 	}
 }
+
+func trySend(errCh chan<- error, err error) {
+	select {
+	case errCh <- err:
+	default:
+	}
+}
+
+// sendLatest sends err into errCh replacing a value that has not been received yet.
+func sendLatest(errCh chan error, err error) {
+	for {
+		select {
+		case errCh <- err:
+			return
+		default:
+		}
+
+		select {
+		case <-errCh:
+		default:
+		}
+	}
+}
+
+// jitter randomly deviates d by up to fraction of it.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 {
+		return d
+	}
+
+	deviation := (rand.Float64()*2 - 1) * fraction // #nosec G404
+	return time.Duration(float64(d) * (1 + deviation))
+}
+
+// truncateUTF8 returns b cut to at most n bytes, without splitting a multi-byte character.
+func truncateUTF8(b []byte, n int) []byte {
+	if n >= len(b) {
+		return b
+	}
+
+	n = max(n, 0)
+	for n > 0 && !utf8.RuneStart(b[n]) {
+		n--
+	}
+	return b[:n]
+}
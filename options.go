@@ -0,0 +1,274 @@
+package alslgr
+
+import (
+	"math"
+	"runtime"
+	"time"
+)
+
+type (
+	Option func(l *logger)
+
+	AutoDumpOption func(s *schedule)
+
+	OverflowPolicy int
+)
+
+const (
+	// OverflowBlock dumps the buffer synchronously inside Write when a record does not fit.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest rejects a record that does not fit with ErrBufferFull.
+	OverflowDropNewest
+	// OverflowDropOldest evicts the oldest buffered records until the new one fits.
+	OverflowDropOldest
+	// OverflowGrowUnbounded grows the buffer beyond its capacity instead of dumping.
+	OverflowGrowUnbounded
+)
+
+func WithOverflowPolicy(policy OverflowPolicy) Option {
+	return func(l *logger) {
+		l.overflowPolicy = policy
+	}
+}
+
+// WithAsync makes Write only enqueue a copy of the record, while a background goroutine moves queued records into
+// the buffer and performs the dumps. The last error occurred in background is returned by the next DumpBuffer or Close.
+func WithAsync(queueSize int) Option {
+	return func(l *logger) {
+		l.queue = make(chan *queuedRecord, queueSize)
+	}
+}
+
+// WithShards splits the buffer into n independently locked shards of capacity/n bytes each, n <= 0 means GOMAXPROCS.
+// A writer keeps writing into the same shard until it contends with another one, then it moves to a free shard. Shards
+// are merged on dump in the order records were written if preserveOrder is set. Otherwise they are merged in shard
+// order, which keeps the order of records written by a goroutine as long as it has not moved between shards, e.g. all
+// records of a single writer, but not across moves under contention.
+func WithShards(n int, preserveOrder bool) Option {
+	return func(l *logger) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		l.shardCount = n
+		l.orderedShards = preserveOrder
+	}
+}
+
+// WithRecordDelimiter makes the logger treat data terminated by delim as a record instead of a single Write, so a dump
+// never contains an incomplete record. A record that does not fit into the capacity is buffered whole and dumped as one
+// unit. The buffer is never sharded in this mode.
+func WithRecordDelimiter(delim byte) Option {
+	return func(l *logger) {
+		l.delimited = true
+		l.recordDelimiter = delim
+	}
+}
+
+// WithBudget makes the logger account buffered data in b shared with other loggers. A record exceeding the limit of b
+// evicts the oldest records of its shard with OverflowDropOldest and is rejected with ErrBufferFull with any other
+// policy, instead of allocating memory.
+func WithBudget(b Budget) Option {
+	return func(l *logger) {
+		l.budget = b
+	}
+}
+
+// WithRetainOnError defines what happens to buffered data when Dump fails. If retain is set, which is the default,
+// the data is kept and dumped again before newer data on the next dump, otherwise it is discarded.
+func WithRetainOnError(retain bool) Option {
+	return func(l *logger) {
+		l.discardOnError = !retain
+	}
+}
+
+// WithMaxRetainedSize limits the size of memory kept for reuse after a dump. Buffers grown beyond size, e.g. by
+// OverflowGrowUnbounded or large records, are released instead of being reused, so memory returns after bursts.
+func WithMaxRetainedSize(size int) Option {
+	return func(l *logger) {
+		l.maxRetainedSize = size
+	}
+}
+
+// WithFlushThreshold makes the logger dump the buffer in background once buffered data reaches size bytes, which is
+// expected to be below the capacity, so writers rarely have to wait for a dump. The last error occurred in background
+// is returned by the next DumpBuffer or Close.
+func WithFlushThreshold(size int) Option {
+	return func(l *logger) {
+		l.flushThreshold = size
+	}
+}
+
+// WithIdleFlush makes the logger dump the buffer in background once no records have been written for the duration,
+// so the tail of a burst is delivered quickly. The last error occurred in background is returned by the next DumpBuffer
+// or Close.
+func WithIdleFlush(idle time.Duration) Option {
+	return func(l *logger) {
+		l.idleFlush = idle
+	}
+}
+
+// WithFraming makes the logger prefix every record with its length and CRC32-C checksum, so dumps can be parsed back
+// into records with NewFrameReader and corruption or truncation is detected. It has no effect with
+// WithRecordDelimiter.
+func WithFraming() Option {
+	return func(l *logger) {
+		l.framed = true
+	}
+}
+
+// WithSequenceNumbers makes the logger prepend a sequence number followed by a space to every record, after the frame
+// header if any. Numbers start at 1 and increase in the order records enter the buffer, which is the order they are
+// dumped in, even with WithShards or WithAsync. It has no effect with WithRecordDelimiter.
+func WithSequenceNumbers() Option {
+	return func(l *logger) {
+		l.stampSeq = true
+	}
+}
+
+// WithMaxRecordSize limits records to size bytes, excluding the timestamp and the prefix. A larger record is rejected
+// with ErrRecordTooLarge if marker is empty, otherwise it is truncated and marker, e.g. "...[truncated]\n", is appended
+// to it so that the result is size bytes long. The limit is applied after middlewares and, with WithRecordDelimiter,
+// to every Write.
+func WithMaxRecordSize(size int, marker string) Option {
+	return func(l *logger) {
+		l.maxRecordSize = size
+		l.truncateMarker = marker
+	}
+}
+
+// WithTimestamp makes the logger prepend the time of every Write formatted with layout and followed by a space to the
+// record. With WithRecordDelimiter, it is prepended only to writes starting a new record.
+func WithTimestamp(layout string) Option {
+	return func(l *logger) {
+		l.timestampLayout = layout
+	}
+}
+
+// WithPrefix makes the logger prepend prefix, e.g. a hostname or an application name, to every record after the
+// timestamp if any. With WithRecordDelimiter, it is prepended only to writes starting a new record.
+func WithPrefix(prefix string) Option {
+	return func(l *logger) {
+		l.prefix = prefix
+	}
+}
+
+// WithRepeatSuppression makes the logger collapse consecutive identical records written to a shard into one, followed
+// by a record formatted with format, e.g. "last record repeated %d times\n", when a different record is written or the
+// buffer is dumped. The marker may exceed the capacity slightly. It has no effect with WithRecordDelimiter.
+func WithRepeatSuppression(format string) Option {
+	return func(l *logger) {
+		l.repeatFormat = format
+	}
+}
+
+// WithDropMarker makes the logger report records discarded by OverflowDropNewest, OverflowDropOldest or a full queue of
+// WithAsync with a record formatted with format, e.g. "%d records dropped\n", appended to the next dump. Drops are
+// counted by Stats regardless. It has no effect with WithRecordDelimiter.
+func WithDropMarker(format string) Option {
+	return func(l *logger) {
+		l.dropFormat = format
+	}
+}
+
+// WithSampler makes the logger drop records beyond the rate configured by cfg, so a runaway loop can not flood the
+// buffer and the sink. Records are sampled after middlewares of WithMiddlewares passed before it. The number of
+// suppressed records, if any, is reported with a record formatted with ReportFormat every Interval and appended to
+// every dump, e.g. by DumpBuffer or Close. Reports are not made with WithRecordDelimiter.
+func WithSampler(cfg SamplerConfig) Option {
+	return func(l *logger) {
+		l.sampler = newSampler(cfg)
+		l.middlewares = append(l.middlewares, l.sampler.sample)
+	}
+}
+
+// WithMiddlewares makes the logger pass every written record through middlewares in the given order before it is
+// buffered. The timestamp and the prefix are prepended to the result.
+func WithMiddlewares(middlewares ...WriteMiddleware) Option {
+	return func(l *logger) {
+		l.middlewares = append(l.middlewares, middlewares...)
+	}
+}
+
+// WithWriteAheadLog makes the logger mirror buffered data into files of dir, which are removed once the data has been
+// dumped, so data left in dir after a crash can be recovered. If sync is set, every write is synced to disk, which
+// protects against power loss at a considerable cost. Write returns errors of the log, the record is buffered anyway.
+func WithWriteAheadLog(dir string, sync bool) Option {
+	return func(l *logger) {
+		l.wal = newWriteAheadLog(dir, sync)
+	}
+}
+
+// WithDumpTimeout makes a dump fail with ErrDumpTimeout once it takes longer than timeout, so a hung sink can not
+// block writers indefinitely. The context passed to a ContextDumper is cancelled, a plain Dumper is left running in
+// background, while the logger keeps a copy of the data. Dumps fail with ErrDumpTimeout without calling the dumper
+// until the abandoned call returns, so the dumper is never called concurrently, and the copy is discarded if the call
+// has succeeded. Close waits for it.
+func WithDumpTimeout(timeout time.Duration) Option {
+	return func(l *logger) {
+		l.dumpTimeout = timeout
+	}
+}
+
+// WithUnhealthyThreshold makes the logger report itself unhealthy only after n consecutive dumps have failed, which is
+// 1 by default.
+func WithUnhealthyThreshold(n int) Option {
+	return func(l *logger) {
+		l.unhealthyThreshold = max(n, 1)
+	}
+}
+
+// WithMetricsRecorder makes the logger report buffered, dumped and dropped data to r in addition to the counters
+// returned by Stats.
+func WithMetricsRecorder(r MetricsRecorder) Option {
+	return func(l *logger) {
+		l.metrics = r
+	}
+}
+
+// WithOnDumpError registers fn to be called with the error and the number of bytes involved every time a dump fails,
+// whether it has been triggered manually or in background. fn is called while dumps are serialized, so it must not
+// dump the logger.
+func WithOnDumpError(fn func(err error, bytes int)) Option {
+	return func(l *logger) {
+		l.onDumpError = fn
+	}
+}
+
+// WithOnFlush registers fn to be called with the number of bytes dumped every time a dump succeeds. Like the one of
+// WithOnDumpError, fn must not dump the logger.
+func WithOnFlush(fn func(bytes int)) Option {
+	return func(l *logger) {
+		l.onFlush = fn
+	}
+}
+
+// WithAutoDumpJitter randomly deviates every interval of an auto dump by up to fraction of it, so many instances do not
+// dump in lockstep.
+func WithAutoDumpJitter(fraction float64) AutoDumpOption {
+	return func(s *schedule) {
+		s.jitter = fraction
+	}
+}
+
+// WithAutoDumpSchedule makes an auto dump happen at times defined by sched, e.g. created by NewCronSchedule or
+// NewClockSchedule, instead of every interval, which is ignored.
+func WithAutoDumpSchedule(sched Schedule) AutoDumpOption {
+	return func(s *schedule) {
+		s.next = func() time.Duration {
+			now := time.Now()
+
+			next := sched.Next(now)
+			if next.IsZero() {
+				return math.MaxInt64
+			}
+			return next.Sub(now)
+		}
+	}
+}
+
+// WithAutoDumpImmediate makes an auto dump perform the first dump right away instead of waiting for an interval.
+func WithAutoDumpImmediate() AutoDumpOption {
+	return func(s *schedule) {
+		s.immediate = true
+	}
+}
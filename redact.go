@@ -0,0 +1,117 @@
+package alslgr
+
+import (
+	"regexp"
+	"strings"
+)
+
+type (
+	// RedactorConfig configures NewRedactor. Values of Keys are masked in key=value, key: value and "key":"value"
+	// forms, keys are matched case-insensitively. Whole matches of Patterns are masked. Mask defaults to "***".
+	RedactorConfig struct {
+		Keys     []string
+		Patterns []*regexp.Regexp
+		Mask     string
+	}
+
+	redactor struct {
+		keys     *regexp.Regexp
+		patterns []*regexp.Regexp
+		mask     []byte
+	}
+)
+
+var (
+	// CreditCardPattern matches sequences of 13 to 19 digits optionally separated by spaces or dashes. A redactor masks
+	// its matches only if they pass the Luhn check, so timestamps and other long numbers are mostly kept.
+	CreditCardPattern = regexp.MustCompile(`\b(?:\d[ -]?){12,18}\d\b`)
+)
+
+// NewRedactor creates a WriteMiddleware masking sensitive data, so it never reaches the buffer.
+func NewRedactor(cfg RedactorConfig) WriteMiddleware {
+	r := &redactor{
+		patterns: cfg.Patterns,
+		mask:     []byte(cfg.Mask),
+	}
+
+	if len(r.mask) == 0 {
+		r.mask = []byte("***")
+	}
+
+	if len(cfg.Keys) > 0 {
+		keys := make([]string, len(cfg.Keys))
+		for i, key := range cfg.Keys {
+			keys[i] = regexp.QuoteMeta(key)
+		}
+
+		r.keys = regexp.MustCompile(`(?i)(\b(?:` + strings.Join(keys, "|") + `)"?\s*[:=]\s*)` +
+			`(?:"(?:[^"\\]|\\.)*"|[^\s,;&}"]+)`)
+	}
+
+	return r.redact
+}
+
+func (r *redactor) redact(b []byte) ([]byte, error) {
+	if r.keys != nil && r.keys.Match(b) {
+		b = r.keys.ReplaceAllFunc(b, r.maskValue)
+	}
+
+	for _, p := range r.patterns {
+		if !p.Match(b) {
+			continue
+		}
+
+		if p == CreditCardPattern {
+			b = p.ReplaceAllFunc(b, r.maskCard)
+		} else {
+			b = p.ReplaceAllLiteral(b, r.mask)
+		}
+	}
+
+	return b, nil
+}
+
+// maskValue masks the value of a key-value pair matched by keys, keeping quotes of a quoted value.
+func (r *redactor) maskValue(match []byte) []byte {
+	loc := r.keys.FindSubmatchIndex(match)
+	prefix, value := match[:loc[3]], match[loc[3]:]
+
+	masked := make([]byte, 0, len(prefix)+len(r.mask)+2)
+	masked = append(masked, prefix...)
+	if value[0] == '"' {
+		masked = append(masked, '"')
+		masked = append(masked, r.mask...)
+		return append(masked, '"')
+	}
+	return append(masked, r.mask...)
+}
+
+// maskCard masks a match of CreditCardPattern if it is a valid card number.
+func (r *redactor) maskCard(match []byte) []byte {
+	if !luhnValid(match) {
+		return match
+	}
+	return r.mask
+}
+
+// luhnValid reports whether the digits of b, ignoring any other bytes, pass the Luhn check.
+func luhnValid(b []byte) bool {
+	sum, double := 0, false
+	for i := len(b) - 1; i >= 0; i-- {
+		if b[i] < '0' || b[i] > '9' {
+			continue
+		}
+
+		digit := int(b[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
@@ -0,0 +1,91 @@
+package alslgr
+
+import (
+	"hash/maphash"
+	"sync"
+	"time"
+)
+
+type (
+	// SamplerConfig configures WithSampler. Rate limits the number of records passed per Interval, which defaults to a
+	// second, Every makes only the first of every Every identical records within an Interval pass. Zero values
+	// disable the corresponding limit. The number of suppressed records is reported with ReportFormat, which defaults to
+	// "%d records suppressed\n".
+	SamplerConfig struct {
+		Rate         int
+		Every        int
+		Interval     time.Duration
+		ReportFormat string
+	}
+
+	sampler struct {
+		mx sync.Mutex
+
+		cfg  SamplerConfig
+		seed maphash.Seed
+
+		windowStart time.Time
+		passed      int
+		seen        map[uint64]int
+		suppressed  int
+
+		now func() time.Time
+	}
+)
+
+func newSampler(cfg SamplerConfig) *sampler {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Second
+	}
+	if cfg.ReportFormat == "" {
+		cfg.ReportFormat = "%d records suppressed\n"
+	}
+
+	return &sampler{
+		cfg:  cfg,
+		seed: maphash.MakeSeed(),
+		seen: make(map[uint64]int),
+		now:  time.Now,
+	}
+}
+
+func (s *sampler) sample(b []byte) ([]byte, error) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	now := s.now()
+	if now.Sub(s.windowStart) >= s.cfg.Interval {
+		s.windowStart = now
+		s.passed = 0
+		clear(s.seen)
+	}
+
+	if s.cfg.Rate > 0 && s.passed >= s.cfg.Rate {
+		s.suppressed++
+		return nil, nil
+	}
+
+	if s.cfg.Every > 1 {
+		h := maphash.Bytes(s.seed, b)
+		n := s.seen[h]
+		s.seen[h] = n + 1
+		if n%s.cfg.Every != 0 {
+			s.suppressed++
+			return nil, nil
+		}
+	}
+
+	s.passed++
+
+	return b, nil
+}
+
+// takeSuppressed returns the number of records suppressed since the previous call.
+func (s *sampler) takeSuppressed() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	n := s.suppressed
+	s.suppressed = 0
+	return n
+}
@@ -0,0 +1,204 @@
+package alslgr
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type (
+	// cronSchedule holds sets of matching values of every field as bit masks.
+	cronSchedule struct {
+		minute, hour, dom, month, dow uint64
+		domAny, dowAny                bool
+	}
+
+	clockSchedule struct {
+		times []time.Duration
+	}
+
+	cronField struct {
+		min, max int
+	}
+)
+
+const (
+	// cronScheduleHorizon is how far ahead Next of a cron schedule looks for a matching time.
+	cronScheduleHorizon = 5
+)
+
+var (
+	cronFields = [...]cronField{
+		{min: 0, max: 59},
+		{min: 0, max: 23},
+		{min: 1, max: 31},
+		{min: 1, max: 12},
+		{min: 0, max: 7},
+	}
+)
+
+// NewCronSchedule parses a standard cron expression of five fields: minute, hour, day of month, month and day of week,
+// where 0 and 7 are Sunday. Fields support lists, ranges and steps, e.g. "0,30 * * * *" or "*/15 9-17 * * 1-5". As in
+// cron, a day matches if either day field does when both are restricted. Times are in the local time zone.
+func NewCronSchedule(expr string) (Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("%w: %q: expected %d fields", ErrInvalidSchedule, expr, len(cronFields))
+	}
+
+	var masks [len(cronFields)]uint64
+	for i, field := range fields {
+		mask, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidSchedule, expr, err)
+		}
+		masks[i] = mask
+	}
+
+	dow := masks[4]
+	if dow&(1<<7) != 0 {
+		dow = dow&^(1<<7) | 1
+	}
+
+	return &cronSchedule{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    dow,
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, f cronField) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepStr)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+
+			var err error
+			lo, err = strconv.Atoi(loStr)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", loStr)
+			}
+
+			hi = lo
+			if isRange {
+				hi, err = strconv.Atoi(hiStr)
+				if err != nil {
+					return 0, fmt.Errorf("invalid value %q", hiStr)
+				}
+			} else if hasStep {
+				hi = f.max
+			}
+		}
+
+		if lo < f.min || hi > f.max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", part, f.min, f.max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << v
+		}
+	}
+
+	return mask, nil
+}
+
+func (c *cronSchedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+
+	horizon := t.AddDate(cronScheduleHorizon, 0, 0)
+	for t.Before(horizon) {
+		switch {
+		case c.month&(1<<t.Month()) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !c.matchDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case c.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case c.minute&(1<<t.Minute()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute()+1, 0, 0, loc)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+func (c *cronSchedule) matchDay(t time.Time) bool {
+	dom := c.dom&(1<<t.Day()) != 0
+	dow := c.dow&(1<<t.Weekday()) != 0
+
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
+
+// NewClockSchedule creates a Schedule matching the given times of every day in the local time zone, formatted as
+// "15:04" or "15:04:05".
+func NewClockSchedule(times ...string) (Schedule, error) {
+	if len(times) == 0 {
+		return nil, fmt.Errorf("%w: no times", ErrInvalidSchedule)
+	}
+
+	s := &clockSchedule{
+		times: make([]time.Duration, 0, len(times)),
+	}
+
+	for _, clock := range times {
+		layout := time.TimeOnly
+		if strings.Count(clock, ":") == 1 {
+			layout = "15:04"
+		}
+
+		t, err := time.Parse(layout, clock)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidSchedule, clock)
+		}
+
+		s.times = append(s.times, time.Duration(t.Hour())*time.Hour+time.Duration(t.Minute())*time.Minute+
+			time.Duration(t.Second())*time.Second)
+	}
+
+	sort.Slice(s.times, func(i, j int) bool {
+		return s.times[i] < s.times[j]
+	})
+
+	return s, nil
+}
+
+func (s *clockSchedule) Next(t time.Time) time.Time {
+	for day := 0; ; day++ {
+		for _, clock := range s.times {
+			next := time.Date(t.Year(), t.Month(), t.Day()+day, 0, 0, int(clock/time.Second), 0, t.Location())
+			if next.After(t) {
+				return next
+			}
+		}
+	}
+}
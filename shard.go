@@ -0,0 +1,224 @@
+package alslgr
+
+import (
+	"bytes"
+	"sync"
+)
+
+type (
+	segment struct {
+		buffer  []byte
+		records []int
+		seqs    []uint64
+
+		// repeats counts suppressed repetitions of the last record, whose header is lastHeader bytes long.
+		repeats    int
+		lastHeader int
+	}
+
+	shard struct {
+		mx sync.Mutex
+
+		active   *segment
+		capacity int
+	}
+)
+
+func newSegment(capacity int) *segment {
+	return &segment{
+		buffer: make([]byte, 0, capacity),
+	}
+}
+
+func (s *segment) append(header, b []byte, seq uint64) {
+	s.lastHeader = len(header)
+	s.buffer = append(s.buffer, header...)
+	s.buffer = append(s.buffer, b...)
+	s.records = append(s.records, len(s.buffer))
+	if seq != 0 {
+		s.seqs = append(s.seqs, seq)
+	}
+}
+
+func (s *segment) appendDelimited(header, b []byte, delim byte) {
+	s.buffer = append(s.buffer, header...)
+	offset := len(s.buffer)
+	s.buffer = append(s.buffer, b...)
+
+	for {
+		i := bytes.IndexByte(b, delim)
+		if i < 0 {
+			return
+		}
+
+		offset += i + 1
+		b = b[i+1:]
+		s.records = append(s.records, offset)
+	}
+}
+
+func (s *segment) complete() int {
+	if len(s.records) == 0 {
+		return 0
+	}
+	return s.records[len(s.records)-1]
+}
+
+// dropOldest drops the least number of the oldest records to free at least n bytes and reports how many records and
+// bytes it has dropped. Nothing is dropped if the segment does not hold n bytes of complete records.
+func (s *segment) dropOldest(n int) (int, int) {
+	i := 0
+	for i < len(s.records) && s.records[i] < n {
+		i++
+	}
+	if i == len(s.records) {
+		return 0, 0
+	}
+
+	cut := s.records[i]
+	s.buffer = s.buffer[:copy(s.buffer, s.buffer[cut:])]
+
+	s.records = s.records[:copy(s.records, s.records[i+1:])]
+	for j := range s.records {
+		s.records[j] -= cut
+	}
+
+	if len(s.seqs) > 0 {
+		s.seqs = s.seqs[:copy(s.seqs, s.seqs[i+1:])]
+	}
+	if len(s.records) == 0 {
+		s.repeats = 0
+	}
+
+	return i + 1, cut
+}
+
+func (s *segment) clone() *segment {
+	return &segment{
+		buffer:     append(make([]byte, 0, cap(s.buffer)), s.buffer...),
+		records:    append([]int(nil), s.records...),
+		seqs:       append([]uint64(nil), s.seqs...),
+		repeats:    s.repeats,
+		lastHeader: s.lastHeader,
+	}
+}
+
+func (s *segment) record(i int) []byte {
+	start := 0
+	if i > 0 {
+		start = s.records[i-1]
+	}
+	return s.buffer[start:s.records[i]]
+}
+
+func (s *segment) reset() {
+	s.buffer = s.buffer[:0]
+	s.records = s.records[:0]
+	s.seqs = s.seqs[:0]
+	s.repeats = 0
+}
+
+// repeated reports whether b is the same as the last record of the segment except for the header.
+func (s *segment) repeated(b []byte) bool {
+	if len(s.records) == 0 {
+		return false
+	}
+	return bytes.Equal(s.record(len(s.records) - 1)[s.lastHeader:], b)
+}
+
+// shrink releases the memory of an empty segment grown beyond maxSize, allocating a buffer of capacity instead.
+func (s *segment) shrink(maxSize, capacity int) {
+	if cap(s.buffer) > maxSize {
+		s.buffer = make([]byte, 0, capacity)
+	}
+	if cap(s.records) > maxSize {
+		s.records = nil
+		s.seqs = nil
+	}
+}
+
+func newShard(capacity int) *shard {
+	return &shard{
+		active:   newSegment(capacity),
+		capacity: capacity,
+	}
+}
+
+func (s *shard) free() int {
+	return s.capacity - len(s.active.buffer)
+}
+
+// swap exchanges the active segment of the shard with the given empty spare one and returns the previously active
+// segment. If keepTail is set, an incomplete record at the end of the active segment is moved to the new one. If seal
+// is not nil, it is called with the active segment right before the swap.
+func (s *shard) swap(spare *segment, keepTail bool, seal func(*segment)) *segment {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+
+	return s.swapLocked(spare, keepTail, seal)
+}
+
+func (s *shard) swapLocked(spare *segment, keepTail bool, seal func(*segment)) *segment {
+	if seal != nil {
+		seal(s.active)
+	}
+
+	active := s.active
+	s.active = spare
+
+	if keepTail {
+		complete := active.complete()
+		s.active.buffer = append(s.active.buffer, active.buffer[complete:]...)
+		active.buffer = active.buffer[:complete]
+	}
+
+	return active
+}
+
+func mergeSegments(dst []byte, segments []*segment, ordered bool) []byte {
+	if !ordered {
+		for _, s := range segments {
+			dst = append(dst, s.buffer...)
+		}
+		return dst
+	}
+
+	forEachOrderedRecord(segments, func(record []byte) {
+		dst = append(dst, record...)
+	})
+	return dst
+}
+
+func segmentRecords(dst [][]byte, segments []*segment, ordered bool) [][]byte {
+	if !ordered {
+		for _, s := range segments {
+			for i := range s.records {
+				dst = append(dst, s.record(i))
+			}
+		}
+		return dst
+	}
+
+	forEachOrderedRecord(segments, func(record []byte) {
+		dst = append(dst, record)
+	})
+	return dst
+}
+
+func forEachOrderedRecord(segments []*segment, f func(record []byte)) {
+	next := make([]int, len(segments))
+	for {
+		first := -1
+		for i, s := range segments {
+			if next[i] < len(s.seqs) && (first < 0 || s.seqs[next[i]] < segments[first].seqs[next[first]]) {
+				first = i
+			}
+		}
+		if first < 0 {
+			return
+		}
+
+		f(segments[first].record(next[first]))
+		next[first]++
+	}
+}
@@ -0,0 +1,91 @@
+package alslgr
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// FlushOnSignal closes the logger once one of signals, SIGINT and SIGTERM by default, is received: auto dumps are
+// stopped, the buffer is dumped and the Dumper is closed. The result of Close is sent into the returned channel, then
+// the signal is raised again, so the process exits, or does whatever else it does on the signal, as if it had not
+// been intercepted. The channel is closed without a value if ctx is done or the logger is closed before a signal is
+// received.
+func (l *logger) FlushOnSignal(ctx context.Context, signals ...os.Signal) <-chan error {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer close(errCh)
+
+		select {
+		case <-ctx.Done():
+			signal.Stop(sigCh)
+		case <-l.ctx.Done():
+			signal.Stop(sigCh)
+		case sig := <-sigCh:
+			errCh <- l.Close()
+			signal.Stop(sigCh)
+			raise(sig)
+		}
+	}()
+
+	return errCh
+}
+
+// raise sends sig to the current process.
+func raise(sig os.Signal) {
+	p, err := os.FindProcess(os.Getpid())
+	if err == nil {
+		_ = p.Signal(sig)
+	}
+}
+
+// ReopenOnSignal reopens the Dumper every time one of signals, SIGHUP by default on unix, is received, until ctx is done
+// or the logger is closed. Reopen errors are sent into the returned channel, which holds the latest error only. On
+// other platforms signals must be given, otherwise errors.ErrUnsupported is sent into the channel, which is closed.
+func (l *logger) ReopenOnSignal(ctx context.Context, signals ...os.Signal) <-chan error {
+	if len(signals) == 0 {
+		signals = reopenSignals
+	}
+
+	errCh := make(chan error, 1)
+
+	if len(signals) == 0 {
+		errCh <- errors.ErrUnsupported
+		close(errCh)
+		return errCh
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+
+	go func() {
+		defer close(errCh)
+		defer signal.Stop(sigCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-l.ctx.Done():
+				return
+			case <-sigCh:
+				err := l.Reopen()
+				if err != nil {
+					sendLatest(errCh, err)
+				}
+			}
+		}
+	}()
+
+	return errCh
+}
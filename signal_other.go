@@ -0,0 +1,13 @@
+//go:build !unix
+
+package alslgr
+
+import (
+	"os"
+)
+
+var (
+	// reopenSignals are the signals ReopenOnSignal reopens the Dumper on if none are given, there is no conventional
+	// one outside unix.
+	reopenSignals []os.Signal
+)
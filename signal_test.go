@@ -0,0 +1,58 @@
+//go:build unix
+
+package alslgr
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestFlushOnSignal(t *testing.T) {
+	d := &ClosingTestDumper{}
+	l := NewLogger(1<<3, d)
+
+	_, err := l.Write([]byte("A"))
+	if err != nil {
+		t.Errorf("TEST \"FLUSH ON SIGNAL\" FAILED: EXPECTED WRITE ERROR \"nil\" GOT \"%v\"\n", err)
+	}
+
+	// The signal raised again after closing must not terminate the test.
+	sigCh := make(chan os.Signal, 2)
+	signal.Notify(sigCh, syscall.SIGUSR1)
+	defer signal.Stop(sigCh)
+
+	errCh := l.FlushOnSignal(context.Background(), syscall.SIGUSR1)
+
+	err = syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	if err != nil {
+		t.Fatalf("FAILED TO SEND SIGNAL: %v\n", err)
+	}
+
+	select {
+	case err = <-errCh:
+		if err != nil {
+			t.Errorf("TEST \"FLUSH ON SIGNAL\" FAILED: EXPECTED CLOSE ERROR \"nil\" GOT \"%v\"\n", err)
+		}
+	case <-time.After(AutoDumpTestDelay):
+		t.Errorf("TEST \"FLUSH ON SIGNAL\" FAILED: EXPECTED LOGGER TO BE CLOSED\n")
+	}
+
+	givenResult := string((*bytes.Buffer)(&d.TestDumper).Bytes())
+	if givenResult != "A" || !d.closed {
+		t.Errorf("TEST \"FLUSH ON SIGNAL\" FAILED: EXPECTED DATA %s AND CLOSED DUMPER GOT %s, %t\n", "A", givenResult, d.closed)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-sigCh:
+		case <-time.After(AutoDumpTestDelay):
+			t.Errorf("TEST \"FLUSH ON SIGNAL\" FAILED: EXPECTED SIGNAL TO BE RAISED AGAIN\n")
+			return
+		}
+	}
+}
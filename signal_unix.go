@@ -0,0 +1,13 @@
+//go:build unix
+
+package alslgr
+
+import (
+	"os"
+	"syscall"
+)
+
+var (
+	// reopenSignals are the signals ReopenOnSignal reopens the Dumper on if none are given.
+	reopenSignals = []os.Signal{syscall.SIGHUP}
+)
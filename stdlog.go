@@ -0,0 +1,56 @@
+package alslgr
+
+import (
+	"errors"
+	"io"
+	"log"
+	"os"
+)
+
+type (
+	// stdWriter writes into the Logger, falling back to another writer once the Logger is closed, so messages logged
+	// during shutdown, e.g. by http.Server after the Logger has been closed, are not lost.
+	stdWriter struct {
+		logger   Logger
+		fallback io.Writer
+	}
+)
+
+// NewStdLogger creates a log.Logger writing every message into the Logger as a single record, e.g. to be used as
+// http.Server.ErrorLog. Messages written after the Logger has been closed go to os.Stderr. The same writer can be
+// passed to grpclog.NewLoggerV2 as the result of NewStdLogger(logger, "", 0).Writer().
+func NewStdLogger(logger Logger, prefix string, flag int) *log.Logger {
+	return log.New(newStdWriter(logger, os.Stderr), prefix, flag)
+}
+
+// RedirectStdLog makes the standard logger of the log package write into the Logger like NewStdLogger does. The
+// returned function restores the previous output and dumps the buffer, it is to be called before the Logger is closed.
+func RedirectStdLog(logger Logger) func() error {
+	prev := log.Writer()
+	log.SetOutput(newStdWriter(logger, os.Stderr))
+
+	return func() error {
+		log.SetOutput(prev)
+
+		err := logger.DumpBuffer()
+		if errors.Is(err, ErrLoggerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+func newStdWriter(logger Logger, fallback io.Writer) *stdWriter {
+	return &stdWriter{
+		logger:   logger,
+		fallback: fallback,
+	}
+}
+
+func (w *stdWriter) Write(b []byte) (int, error) {
+	n, err := w.logger.Write(b)
+	if errors.Is(err, ErrLoggerClosed) {
+		return w.fallback.Write(b)
+	}
+	return n, err
+}
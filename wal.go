@@ -0,0 +1,167 @@
+package alslgr
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+type (
+	// writeAheadLog mirrors buffered data into files of a directory. Files are sealed when the buffer is swapped and
+	// removed once everything swapped has been dumped, so the directory holds only data that has not been dumped.
+	writeAheadLog struct {
+		mx sync.Mutex
+
+		dir  string
+		sync bool
+
+		gen    uint64
+		file   *os.File
+		size   int
+		sealed []string
+		err    error
+	}
+)
+
+const (
+	walFileExt = ".wal"
+)
+
+// Recover dumps data left in the write-ahead log directory dir by a previous run, oldest file first, and removes every
+// file once it has been dumped. It stops at the first error, keeping files not dumped yet. Recover must be called
+// before a Logger using dir is created.
+func Recover(dir string, dumper Dumper) error {
+	names, err := filepath.Glob(filepath.Join(dir, "*"+walFileExt))
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+
+		if len(b) > 0 {
+			err = dumper.Dump(b)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = os.Remove(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newWriteAheadLog(dir string, sync bool) *writeAheadLog {
+	return &writeAheadLog{
+		dir:  dir,
+		sync: sync,
+		gen:  uint64(time.Now().UnixNano()),
+	}
+}
+
+func (w *writeAheadLog) append(b []byte) error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	return w.appendLocked(b)
+}
+
+func (w *writeAheadLog) appendLocked(b []byte) error {
+	if w.file == nil {
+		err := w.open()
+		if err != nil {
+			return err
+		}
+	}
+
+	n, err := w.file.Write(b)
+	w.size += n
+	if err != nil {
+		return err
+	}
+
+	if w.sync {
+		return w.file.Sync()
+	}
+
+	return nil
+}
+
+func (w *writeAheadLog) open() error {
+	err := os.MkdirAll(w.dir, 0750)
+	if err != nil {
+		return err
+	}
+
+	w.gen++
+	name := filepath.Join(w.dir, fmt.Sprintf("%020d%s", w.gen, walFileExt))
+
+	w.file, err = os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_APPEND|os.O_WRONLY, FileDumperDefaultPerms)
+	return err
+}
+
+// rotate seals the current file, so data appended later goes into a new one, starting with tail.
+func (w *writeAheadLog) rotate(tail []byte) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if w.file != nil {
+		w.err = errors.Join(w.err, w.closeFile())
+		if w.size > 0 {
+			w.sealed = append(w.sealed, w.file.Name())
+		}
+		w.file, w.size = nil, 0
+	}
+
+	if len(tail) > 0 {
+		w.err = errors.Join(w.err, w.appendLocked(tail))
+	}
+}
+
+// release removes sealed files and returns errors occurred since the previous call.
+func (w *writeAheadLog) release() error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	err := w.err
+	w.err = nil
+
+	for _, name := range w.sealed {
+		err = errors.Join(err, os.Remove(name))
+	}
+	w.sealed = w.sealed[:0]
+
+	return err
+}
+
+func (w *writeAheadLog) close() error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+
+	return w.closeFile()
+}
+
+// closeFile closes the current file and removes it if it is empty.
+func (w *writeAheadLog) closeFile() error {
+	err := w.file.Close()
+	if w.size == 0 {
+		err = errors.Join(err, os.Remove(w.file.Name()))
+	}
+	return err
+}
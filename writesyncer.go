@@ -0,0 +1,27 @@
+package alslgr
+
+import (
+	"errors"
+)
+
+type (
+	writeSyncer struct {
+		Logger
+	}
+)
+
+// NewWriteSyncer adapts the Logger to zapcore.WriteSyncer. Sync is the one of the Logger, it is a no-op once the Logger
+// is closed, since Close has already dumped everything, so a deferred Sync after Close does not report an error.
+func NewWriteSyncer(logger Logger) WriteSyncer {
+	return &writeSyncer{
+		Logger: logger,
+	}
+}
+
+func (w *writeSyncer) Sync() error {
+	err := w.Logger.Sync()
+	if errors.Is(err, ErrLoggerClosed) {
+		return nil
+	}
+	return err
+}